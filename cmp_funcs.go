@@ -13,6 +13,16 @@ import (
 	"time"
 )
 
+// CmpAfter is a shortcut for:
+//
+//   CmpDeeply(t, got, After(expectedTime), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpAfter(t *testing.T, got interface{}, expectedTime interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, After(expectedTime), args...)
+}
+
 // CmpAll is a shortcut for:
 //
 //   CmpDeeply(t, got, All(expectedValues...), args...)
@@ -37,6 +47,9 @@ func CmpAny(t *testing.T, got interface{}, expectedValues []interface{}, args ..
 //
 //   CmpDeeply(t, got, Array(model, expectedEntries), args...)
 //
+// Array() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with Array() to pass it.
+//
 // Returns true if the test is OK, false if it fails.
 func CmpArray(t *testing.T, got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
 	t.Helper()
@@ -53,6 +66,26 @@ func CmpArrayEach(t *testing.T, got interface{}, expectedValue interface{}, args
 	return CmpDeeply(t, got, ArrayEach(expectedValue), args...)
 }
 
+// CmpAtLeast is a shortcut for:
+//
+//   CmpDeeply(t, got, AtLeast(expectedValue, min), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpAtLeast(t *testing.T, got interface{}, expectedValue interface{}, min int, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, AtLeast(expectedValue, min), args...)
+}
+
+// CmpAtMost is a shortcut for:
+//
+//   CmpDeeply(t, got, AtMost(expectedValue, max), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpAtMost(t *testing.T, got interface{}, expectedValue interface{}, max int, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, AtMost(expectedValue, max), args...)
+}
+
 // CmpBag is a shortcut for:
 //
 //   CmpDeeply(t, got, Bag(expectedItems...), args...)
@@ -63,6 +96,26 @@ func CmpBag(t *testing.T, got interface{}, expectedItems []interface{}, args ...
 	return CmpDeeply(t, got, Bag(expectedItems...), args...)
 }
 
+// CmpBagBy is a shortcut for:
+//
+//   CmpDeeply(t, got, BagBy(by, expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpBagBy(t *testing.T, got interface{}, by interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, BagBy(by, expectedItems...), args...)
+}
+
+// CmpBefore is a shortcut for:
+//
+//   CmpDeeply(t, got, Before(expectedTime), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpBefore(t *testing.T, got interface{}, expectedTime interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Before(expectedTime), args...)
+}
+
 // CmpBetween is a shortcut for:
 //
 //   CmpDeeply(t, got, Between(from, to, bounds), args...)
@@ -107,6 +160,26 @@ func CmpContains(t *testing.T, got interface{}, expected string, args ...interfa
 	return CmpDeeply(t, got, Contains(expected), args...)
 }
 
+// CmpCount is a shortcut for:
+//
+//   CmpDeeply(t, got, Count(expectedValue, count), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpCount(t *testing.T, got interface{}, expectedValue interface{}, count interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Count(expectedValue, count), args...)
+}
+
+// CmpFinite is a shortcut for:
+//
+//   CmpDeeply(t, got, Finite(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpFinite(t *testing.T, got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Finite(), args...)
+}
+
 // CmpGt is a shortcut for:
 //
 //   CmpDeeply(t, got, Gt(val), args...)
@@ -147,6 +220,16 @@ func CmpHasSuffix(t *testing.T, got interface{}, expected string, args ...interf
 	return CmpDeeply(t, got, HasSuffix(expected), args...)
 }
 
+// CmpInf is a shortcut for:
+//
+//   CmpDeeply(t, got, Inf(sign), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpInf(t *testing.T, got interface{}, sign int, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Inf(sign), args...)
+}
+
 // CmpIsa is a shortcut for:
 //
 //   CmpDeeply(t, got, Isa(model), args...)
@@ -207,6 +290,26 @@ func CmpMapEach(t *testing.T, got interface{}, expectedValue interface{}, args .
 	return CmpDeeply(t, got, MapEach(expectedValue), args...)
 }
 
+// CmpMapEachEntry is a shortcut for:
+//
+//   CmpDeeply(t, got, MapEachEntry(fn), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpMapEachEntry(t *testing.T, got interface{}, fn interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, MapEachEntry(fn), args...)
+}
+
+// CmpMapEachKey is a shortcut for:
+//
+//   CmpDeeply(t, got, MapEachKey(expectedKey), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpMapEachKey(t *testing.T, got interface{}, expectedKey interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, MapEachKey(expectedKey), args...)
+}
+
 // CmpN is a shortcut for:
 //
 //   CmpDeeply(t, got, N(num, tolerance), args...)
@@ -221,6 +324,36 @@ func CmpN(t *testing.T, got interface{}, num interface{}, tolerance interface{},
 	return CmpDeeply(t, got, N(num, tolerance), args...)
 }
 
+// CmpNRel is a shortcut for:
+//
+//   CmpDeeply(t, got, NRel(num, relTol), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpNRel(t *testing.T, got interface{}, num interface{}, relTol float64, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, NRel(num, relTol), args...)
+}
+
+// CmpNULP is a shortcut for:
+//
+//   CmpDeeply(t, got, NULP(num, ulps), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpNULP(t *testing.T, got interface{}, num interface{}, ulps uint, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, NULP(num, ulps), args...)
+}
+
+// CmpNaN is a shortcut for:
+//
+//   CmpDeeply(t, got, NaN(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpNaN(t *testing.T, got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, NaN(), args...)
+}
+
 // CmpNil is a shortcut for:
 //
 //   CmpDeeply(t, got, Nil(), args...)
@@ -261,6 +394,16 @@ func CmpNot(t *testing.T, got interface{}, expected interface{}, args ...interfa
 	return CmpDeeply(t, got, Not(expected), args...)
 }
 
+// CmpNotNaN is a shortcut for:
+//
+//   CmpDeeply(t, got, NotNaN(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpNotNaN(t *testing.T, got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, NotNaN(), args...)
+}
+
 // CmpNotNil is a shortcut for:
 //
 //   CmpDeeply(t, got, NotNil(), args...)
@@ -315,6 +458,40 @@ func CmpReAll(t *testing.T, got interface{}, reg interface{}, capture interface{
 	return CmpDeeply(t, got, ReAll(reg, capture), args...)
 }
 
+// CmpRecent is a shortcut for:
+//
+//   CmpDeeply(t, got, Recent(d), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpRecent(t *testing.T, got interface{}, d time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Recent(d), args...)
+}
+
+// CmpRoundTime is a shortcut for:
+//
+//   CmpDeeply(t, got, RoundTime(expectedTime, round), args...)
+//
+// RoundTime() optional parameter "round" is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original RoundTime() call.
+//
+// Returns true if the test is OK, false if it fails.
+func CmpRoundTime(t *testing.T, got interface{}, expectedTime interface{}, round time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, RoundTime(expectedTime, round), args...)
+}
+
+// CmpSStruct is a shortcut for:
+//
+//   CmpDeeply(t, got, SStruct(model, expectedFields), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSStruct(t *testing.T, got interface{}, model interface{}, expectedFields StructFields, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, SStruct(model, expectedFields), args...)
+}
+
 // CmpSet is a shortcut for:
 //
 //   CmpDeeply(t, got, Set(expectedItems...), args...)
@@ -325,6 +502,16 @@ func CmpSet(t *testing.T, got interface{}, expectedItems []interface{}, args ...
 	return CmpDeeply(t, got, Set(expectedItems...), args...)
 }
 
+// CmpSetBy is a shortcut for:
+//
+//   CmpDeeply(t, got, SetBy(by, expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSetBy(t *testing.T, got interface{}, by interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, SetBy(by, expectedItems...), args...)
+}
+
 // CmpShallow is a shortcut for:
 //
 //   CmpDeeply(t, got, Shallow(expectedPtr), args...)
@@ -339,12 +526,45 @@ func CmpShallow(t *testing.T, got interface{}, expectedPtr interface{}, args ...
 //
 //   CmpDeeply(t, got, Slice(model, expectedEntries), args...)
 //
+// Slice() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with Slice() to pass it.
+//
 // Returns true if the test is OK, false if it fails.
 func CmpSlice(t *testing.T, got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
 	t.Helper()
 	return CmpDeeply(t, got, Slice(model, expectedEntries), args...)
 }
 
+// CmpSliceHasPrefix is a shortcut for:
+//
+//   CmpDeeply(t, got, SliceHasPrefix(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSliceHasPrefix(t *testing.T, got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, SliceHasPrefix(expectedItems...), args...)
+}
+
+// CmpSliceHasSuffix is a shortcut for:
+//
+//   CmpDeeply(t, got, SliceHasSuffix(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSliceHasSuffix(t *testing.T, got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, SliceHasSuffix(expectedItems...), args...)
+}
+
+// CmpSorted is a shortcut for:
+//
+//   CmpDeeply(t, got, Sorted(how...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSorted(t *testing.T, got interface{}, how []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Sorted(how...), args...)
+}
+
 // CmpString is a shortcut for:
 //
 //   CmpDeeply(t, got, String(expected), args...)
@@ -365,6 +585,20 @@ func CmpStruct(t *testing.T, got interface{}, model interface{}, expectedFields
 	return CmpDeeply(t, got, Struct(model, expectedFields), args...)
 }
 
+// CmpStructLike is a shortcut for:
+//
+//   CmpDeeply(t, got, StructLike(expected, tag), args...)
+//
+// StructLike() optional parameter "tag" is here mandatory.
+// "" value should be passed to mimic its absence in
+// original StructLike() call.
+//
+// Returns true if the test is OK, false if it fails.
+func CmpStructLike(t *testing.T, got interface{}, expected interface{}, tag string, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, StructLike(expected, tag), args...)
+}
+
 // CmpSubBagOf is a shortcut for:
 //
 //   CmpDeeply(t, got, SubBagOf(expectedItems...), args...)
@@ -395,6 +629,16 @@ func CmpSubSetOf(t *testing.T, got interface{}, expectedItems []interface{}, arg
 	return CmpDeeply(t, got, SubSetOf(expectedItems...), args...)
 }
 
+// CmpSubsequence is a shortcut for:
+//
+//   CmpDeeply(t, got, Subsequence(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSubsequence(t *testing.T, got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Subsequence(expectedItems...), args...)
+}
+
 // CmpSuperBagOf is a shortcut for:
 //
 //   CmpDeeply(t, got, SuperBagOf(expectedItems...), args...)
@@ -425,6 +669,19 @@ func CmpSuperSetOf(t *testing.T, got interface{}, expectedItems []interface{}, a
 	return CmpDeeply(t, got, SuperSetOf(expectedItems...), args...)
 }
 
+// CmpSuperSliceOf is a shortcut for:
+//
+//   CmpDeeply(t, got, SuperSliceOf(model, expectedEntries), args...)
+//
+// SuperSliceOf() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with SuperSliceOf() to pass it.
+//
+// Returns true if the test is OK, false if it fails.
+func CmpSuperSliceOf(t *testing.T, got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, SuperSliceOf(model, expectedEntries), args...)
+}
+
 // CmpTruncTime is a shortcut for:
 //
 //   CmpDeeply(t, got, TruncTime(expectedTime, trunc), args...)
@@ -439,6 +696,30 @@ func CmpTruncTime(t *testing.T, got interface{}, expectedTime interface{}, trunc
 	return CmpDeeply(t, got, TruncTime(expectedTime, trunc), args...)
 }
 
+// CmpUnique is a shortcut for:
+//
+//   CmpDeeply(t, got, Unique(by), args...)
+//
+// Unique() optional parameter "by" is here mandatory.
+// nil value should be passed to mimic its absence in
+// original Unique() call.
+//
+// Returns true if the test is OK, false if it fails.
+func CmpUnique(t *testing.T, got interface{}, by interface{}, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Unique(by), args...)
+}
+
+// CmpWithin is a shortcut for:
+//
+//   CmpDeeply(t, got, Within(expectedTime, d), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func CmpWithin(t *testing.T, got interface{}, expectedTime interface{}, d time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return CmpDeeply(t, got, Within(expectedTime, d), args...)
+}
+
 // CmpZero is a shortcut for:
 //
 //   CmpDeeply(t, got, Zero(), args...)
@@ -12,14 +12,34 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/maxatome/go-testdeep"
 )
 
+func ExampleCmpAfter() {
+	t := &testing.T{}
+
+	start := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := start.Add(time.Minute)
+
+	ok := CmpAfter(t, got, start, "checks %v is after %v", got, start)
+	fmt.Println(ok)
+
+	ok = CmpAfter(t, start, got, "checks %v is after %v", start, got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpAll() {
 	t := &testing.T{}
 
@@ -163,6 +183,32 @@ func ExampleCmpArrayEach_typedSlice() {
 	// true
 }
 
+func ExampleCmpAtLeast() {
+	t := &testing.T{}
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := CmpAtLeast(t, got, Gt(10), 2,
+		"checks at least 2 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleCmpAtMost() {
+	t := &testing.T{}
+
+	got := map[string]int{"a": 12, "b": 3, "c": 20}
+
+	ok := CmpAtMost(t, got, Lt(10), 1,
+		"checks at most 1 value of %v is < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleCmpBag() {
 	t := &testing.T{}
 
@@ -198,6 +244,65 @@ func ExampleCmpBag() {
 	// true
 }
 
+func ExampleCmpBagBy() {
+	t := &testing.T{}
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 3, Name: "Charlie"},
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+	charlie := Record{ID: 3, Name: "Charlie"}
+
+	// Matches as all records are present, in any order
+	ok := CmpBagBy(t, got, "ID", []interface{}{alice, bob, charlie},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #3 is not expected
+	ok = CmpBagBy(t, got, "ID", []interface{}{alice, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #2 differs
+	bobby := Record{ID: 2, Name: "Bobby"}
+	ok = CmpBagBy(t, got, "ID", []interface{}{alice, bobby, charlie},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
+func ExampleCmpBefore() {
+	t := &testing.T{}
+
+	deadline := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := deadline.Add(-time.Minute)
+
+	ok := CmpBefore(t, got, deadline,
+		"checks %v is before %v", got, deadline)
+	fmt.Println(ok)
+
+	ok = CmpBefore(t, deadline, deadline,
+		"checks %v is before %v", deadline, deadline)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpBetween() {
 	t := &testing.T{}
 
@@ -232,6 +337,32 @@ func ExampleCmpBetween() {
 	// false
 }
 
+func ExampleCmpBetween_ordered() {
+	t := &testing.T{}
+
+	// Strings are compared lexically
+	ok := CmpBetween(t, "abc", "abb", "abd", BoundsInIn,
+		`checks "abc" is in ["abb" .. "abd"]`)
+	fmt.Println(ok)
+
+	// Integers of different sizes can be compared
+	got := int64(156)
+	ok = CmpBetween(t, got, 154, 156, BoundsInIn,
+		"checks %v is in [154 .. 156]", got)
+	fmt.Println(ok)
+
+	// Types with a Cmp method, as *big.Int
+	gotBig := big.NewInt(156)
+	ok = CmpBetween(t, gotBig, big.NewInt(154), big.NewInt(156), BoundsInIn,
+		"checks %v is in [154 .. 156]", gotBig)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// true
+}
+
 func ExampleCmpCap() {
 	t := &testing.T{}
 
@@ -326,6 +457,48 @@ func ExampleCmpContains_error() {
 	// true
 }
 
+func ExampleCmpCount() {
+	t := &testing.T{}
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := CmpCount(t, got, Gt(10), 3,
+		"checks 3 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	ok = CmpCount(t, got, Lt(10), Between(1, 3),
+		"checks 1 to 3 items of %v are < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
+func ExampleCmpFinite() {
+	t := &testing.T{}
+
+	got := 12.5
+
+	ok := CmpFinite(t, got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.Inf(1)
+
+	ok = CmpFinite(t, got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = CmpFinite(t, got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
 func ExampleCmpGt() {
 	t := &testing.T{}
 
@@ -428,6 +601,26 @@ func ExampleCmpHasSuffix_error() {
 	// true
 }
 
+func ExampleCmpInf() {
+	t := &testing.T{}
+
+	got := math.Inf(-1)
+
+	ok := CmpInf(t, got, -1, "checks %v is -Inf", got)
+	fmt.Println(ok)
+
+	ok = CmpInf(t, got, 0, "checks %v is an infinity", got)
+	fmt.Println(ok)
+
+	ok = CmpInf(t, got, 1, "checks %v is +Inf", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleCmpIsa() {
 	t := &testing.T{}
 
@@ -661,6 +854,57 @@ func ExampleCmpMapEach_typedMap() {
 	// true
 }
 
+func ExampleCmpMapEachEntry() {
+	t := &testing.T{}
+
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	got := map[int]User{
+		1: {ID: 1, Name: "Alice"},
+		2: {ID: 2, Name: "Bob"},
+	}
+
+	ok := CmpMapEachEntry(t, got, func(id int, user User) bool { return user.ID == id },
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	got[3] = User{ID: 4, Name: "Charlie"}
+	ok = CmpMapEachEntry(t, got, func(id int, user User) (bool, string) {
+		if user.ID != id {
+			return false, "ID field must be the same as the key"
+		}
+		return true, ""
+	},
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpMapEachKey() {
+	t := &testing.T{}
+
+	got := map[string]int{"id_1": 12, "id_2": 42, "id_3": 89}
+
+	ok := CmpMapEachKey(t, got, Re(`^id_\d+$`),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	got["id_x"] = 0
+	ok = CmpMapEachKey(t, got, Re(`^id_\d+$`),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpN() {
 	t := &testing.T{}
 
@@ -674,6 +918,62 @@ func ExampleCmpN() {
 	// true
 }
 
+func ExampleCmpNRel() {
+	t := &testing.T{}
+
+	got := 1000.0000001
+
+	ok := CmpNRel(t, got, 1000.0, 1e-9,
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	got = 1000.001
+
+	ok = CmpNRel(t, got, 1000.0, 1e-9,
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpNULP() {
+	t := &testing.T{}
+
+	a, b := 0.1, 0.2
+	got := a + b
+
+	ok := CmpNULP(t, got, 0.3, 1,
+		"checks %v = 0.3 ± 1 ULP", got)
+	fmt.Println(ok)
+
+	ok = CmpNULP(t, got, 0.3, 0,
+		"checks %v = 0.3 exactly", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpNaN() {
+	t := &testing.T{}
+
+	got := math.NaN()
+
+	ok := CmpNaN(t, got, "checks %v is NaN", got)
+	fmt.Println(ok)
+
+	// NaN is not equal to NaN, even using NaN value
+	ok = CmpDeeply(t, got, math.NaN())
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpNil() {
 	t := &testing.T{}
 
@@ -773,6 +1073,24 @@ func ExampleCmpNot() {
 	// false
 }
 
+func ExampleCmpNotNaN() {
+	t := &testing.T{}
+
+	got := 12.5
+
+	ok := CmpNotNaN(t, got, "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = CmpNotNaN(t, got, "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpNotNil() {
 	t := &testing.T{}
 
@@ -1049,6 +1367,93 @@ func ExampleCmpReAll_compiledCaptureComplex() {
 	// false
 }
 
+func ExampleCmpRecent() {
+	t := &testing.T{}
+
+	// Typically the creation date of a freshly created record
+	got := time.Now()
+
+	ok := CmpRecent(t, got, time.Second,
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	got = got.Add(-time.Hour)
+
+	ok = CmpRecent(t, got, time.Second,
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpRoundTime() {
+	t := &testing.T{}
+
+	got := time.Date(2018, time.May, 1, 12, 45, 53, 600000000, time.UTC)
+
+	// Compare dates rounded to the second
+	expected := time.Date(2018, time.May, 1, 12, 45, 54, 0, time.UTC)
+	ok := CmpRoundTime(t, got, expected, time.Second,
+		"checks date %v, rounded to the second", got)
+	fmt.Println(ok)
+
+	// Truncation does not give the same result
+	ok = CmpDeeply(t, got, TruncTime(expected, time.Second),
+		"checks date %v, truncated to the second", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpSStruct() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name        string
+		Age         int
+		NumChildren int
+	}
+
+	got := Person{
+		Name:        "Foobar",
+		Age:         42,
+		NumChildren: 0,
+	}
+
+	// NumChildren is zero in SStruct() call, so it is checked too
+	ok := CmpSStruct(t, got, Person{Name: "Foobar"}, StructFields{
+		"Age": Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Model can be empty
+	ok = CmpSStruct(t, got, Person{}, StructFields{
+		"Name": "Foobar",
+		"Age":  Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// NumChildren is not zero, but absent from SStruct() call
+	got.NumChildren = 3
+	ok = CmpSStruct(t, &got, &Person{}, StructFields{
+		"Name": "Foobar",
+		"Age":  Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleCmpSet() {
 	t := &testing.T{}
 
@@ -1076,6 +1481,39 @@ func ExampleCmpSet() {
 	// true
 }
 
+func ExampleCmpSetBy() {
+	t := &testing.T{}
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 1, Name: "Alice"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+
+	// Matches as all records are present, ignoring duplicates
+	ok := CmpSetBy(t, got, "ID", []interface{}{alice, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #1 differs
+	alicia := Record{ID: 1, Name: "Alicia"}
+	ok = CmpSetBy(t, got, "ID", []interface{}{alicia, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpShallow() {
 	t := &testing.T{}
 
@@ -1132,6 +1570,90 @@ func ExampleCmpSlice_typedSlice() {
 	// true
 }
 
+func ExampleCmpSliceHasPrefix() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := CmpSliceHasPrefix(t, got, []interface{}{"start", "open"},
+		"checks %v starts with start & open", got)
+	fmt.Println(ok)
+
+	ok = CmpSliceHasPrefix(t, got, []interface{}{"start", Not("open")},
+		"checks %v second item is not open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpSliceHasSuffix() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := CmpSliceHasSuffix(t, got, []interface{}{"close", "stop"},
+		"checks %v ends with close & stop", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleCmpSorted() {
+	t := &testing.T{}
+
+	got := []int{1, 3, 3, 7}
+
+	ok := CmpSorted(t, got, nil, "checks %v is sorted", got)
+	fmt.Println(ok)
+
+	ok = CmpSorted(t, got, []interface{}{-1}, "checks %v is sorted in descending order", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpSorted_fields() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := []Person{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 42},
+		{Name: "Bob", Age: 20},
+	}
+
+	ok := CmpSorted(t, got, []interface{}{"Name", "-Age"},
+		"checks persons are sorted by name, then by age in descending order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleCmpSorted_lessFunc() {
+	t := &testing.T{}
+
+	got := []string{"c", "ab", "xyz"}
+
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+
+	ok := CmpSorted(t, got, []interface{}{byLen},
+		"checks %v is sorted by string length", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleCmpString() {
 	t := &testing.T{}
 
@@ -1215,6 +1737,52 @@ func ExampleCmpStruct() {
 	// true
 }
 
+func ExampleCmpStructLike() {
+	t := &testing.T{}
+
+	type PersonDTO struct {
+		Name     string `json:"name"`
+		Age      int    `json:"age"`
+		Password string `json:"-"`
+	}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := PersonDTO{
+		Name:     "Foobar",
+		Age:      42,
+		Password: "secret",
+	}
+
+	// Types differ, but fields are matched by name: Password is extra
+	person := Person{Name: "Foobar", Age: 42}
+	ok := CmpStructLike(t, got, person, "",
+		"checks %v is like a Person")
+	fmt.Println(ok)
+
+	// Using a map and json tags, Password is ignored
+	entries := map[string]interface{}{
+		"name": "Foobar",
+		"age":  Between(40, 45),
+	}
+	ok = CmpStructLike(t, got, entries, "json",
+		"checks %v is like the map, using json tags")
+	fmt.Println(ok)
+
+	// Person fields are named Name & Age, not name & age
+	ok = CmpStructLike(t, got, person, "json",
+		"checks %v is like a Person, using json tags")
+	fmt.Println(ok)
+
+	// Output:
+	// false
+	// true
+	// false
+}
+
 func ExampleCmpSubBagOf() {
 	t := &testing.T{}
 
@@ -1295,6 +1863,24 @@ func ExampleCmpSubSetOf() {
 	// true
 }
 
+func ExampleCmpSubsequence() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "read", "close", "stop"}
+
+	ok := CmpSubsequence(t, got, []interface{}{"open", "read", "close"},
+		"checks %v contains open, read & close in this order", got)
+	fmt.Println(ok)
+
+	ok = CmpSubsequence(t, got, []interface{}{"close", "open"},
+		"checks %v contains close then open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleCmpSuperBagOf() {
 	t := &testing.T{}
 
@@ -1365,6 +1951,24 @@ func ExampleCmpSuperSetOf() {
 	// true
 }
 
+func ExampleCmpSuperSliceOf() {
+	t := &testing.T{}
+
+	got := []int{42, 58, 26, 12, 7}
+
+	ok := CmpSuperSliceOf(t, got, []int{42}, ArrayEntries{1: Gt(50)},
+		"checks first items of slice %v", got)
+	fmt.Println(ok)
+
+	ok = CmpSuperSliceOf(t, got, []int{42}, ArrayEntries{-1: 7},
+		"checks first & last items of slice %v", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
 func ExampleCmpTruncTime() {
 	t := &testing.T{}
 
@@ -1402,6 +2006,72 @@ func ExampleCmpTruncTime() {
 	// true
 }
 
+func ExampleCmpUnique() {
+	t := &testing.T{}
+
+	got := []int{1, 3, 2, 7}
+
+	ok := CmpUnique(t, got, nil, "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleCmpUnique_key() {
+	t := &testing.T{}
+
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	got := []Person{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "bob"},
+	}
+
+	ok := CmpUnique(t, got, "ID", "checks IDs are unique")
+	fmt.Println(ok)
+
+	lowerName := func(p Person) string { return strings.ToLower(p.Name) }
+
+	ok = CmpUnique(t, got, lowerName,
+		"checks names are unique, case insensitively")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleCmpWithin() {
+	t := &testing.T{}
+
+	expected := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := expected.Add(3 * time.Second)
+
+	ok := CmpWithin(t, got, expected, 5*time.Second,
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Locations do not matter
+	paris := time.FixedZone("CET", 3600)
+	ok = CmpWithin(t, got.In(paris), expected, 5*time.Second,
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	ok = CmpWithin(t, got, expected, time.Second,
+		"checks %v is within 1s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleCmpZero() {
 	t := &testing.T{}
 
@@ -0,0 +1,174 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ContextConfig allows to configure finely how values are compared
+// and how tests failures are rendered.
+//
+// See NewT function to use it.
+type ContextConfig struct {
+	// Following fields configure how failures are rendered
+
+	// MaxOutputLen is the maximum number of bytes used to render each
+	// got, expected or summary value in a failure report. 0 means no
+	// limit.
+	MaxOutputLen int
+	// MaxItems is the maximum number of items (or entries, or fields)
+	// displayed for each collection in a failure report. 0 means no
+	// limit. It also limits the number of reasons listed by Any and
+	// All operators, 10 by default.
+	MaxItems int
+	// MaxDepth is the maximum nesting depth of collections displayed
+	// in a failure report. 0 means no limit.
+	MaxDepth int
+	// DumpTruncated, if true, writes the complete untruncated value in
+	// a temporary file each time a value is truncated due to one of the
+	// limits above. The path of this file is then printed in the
+	// failure report.
+	DumpTruncated bool
+	// ShowExpectedTree, if true, appends to each failure report the
+	// tree of TestDeep operators involved in the expected value, the
+	// branch leading to the failing operator being marked.
+	ShowExpectedTree bool
+	// ReportIgnoredDiffs, if true, lists in each failure report the
+	// differences found in fields ignored by IgnoreFields,
+	// IgnoreFieldTypes, IgnoreFieldTags, IgnoreUnexported or
+	// IgnoreUnexportedOf rules.
+	ReportIgnoredDiffs bool
+
+	// Following fields configure how values are compared
+
+	// StructsByName, if true, compares structs of different types field
+	// by field, fields being matched by name. A struct can also be
+	// compared this way to a map whose keys are strings. See
+	// StructLike operator for details.
+	StructsByName bool
+	// StructsByNameTag, if not empty, is the struct tag (as "json")
+	// used to name fields when StructsByName is true.
+	StructsByNameTag string
+	// IgnoreFields lists the names of the struct fields not compared,
+	// at any depth. Each name can also be a regexp prefixed by "=~" or
+	// a glob pattern, as for StructFields keys. Ignored fields are
+	// skipped by CmpDeeply as well as by Struct & co. operators, except
+	// those explicitly listed in StructFields which are always checked.
+	// It is the same for all the other ignore rules below.
+	IgnoreFields []string
+	// IgnoreFieldTypes lists the types of the struct fields not
+	// compared, at any depth, as reflect.TypeOf(sync.Mutex{}).
+	IgnoreFieldTypes []reflect.Type
+	// IgnoreFieldTags lists the struct tags of the struct fields not
+	// compared, at any depth. Each tag is either a key alone (as
+	// "testdeep") to ignore all fields having this tag key, or a
+	// key/value pair (as `testdeep:"-"`) to ignore fields having this
+	// tag value.
+	IgnoreFieldTags []string
+	// IgnoreUnexported, if true, ignores all unexported struct fields,
+	// at any depth. By default, unexported fields are compared as
+	// exported ones, using the unsafe package if needed.
+	IgnoreUnexported bool
+	// IgnoreUnexportedOf lists the struct types whose unexported fields
+	// are ignored, at any depth.
+	IgnoreUnexportedOf []reflect.Type
+	// UnorderedSlices, if true, compares all slices without taking
+	// care of the order of their items, at any depth, as if each
+	// expected slice was wrapped in a Bag operator.
+	UnorderedSlices bool
+	// UnorderedSliceTypes lists the types of the items of the slices
+	// compared without taking care of the order of their items, at any
+	// depth, as reflect.TypeOf(Role{}).
+	UnorderedSliceTypes []reflect.Type
+	// UnorderedSlicePaths lists the paths of the slices compared
+	// without taking care of the order of their items. Each path is a
+	// JSON Pointer (see Path.JSONPointer method), as "/Users/0/Roles",
+	// that can also be a regexp prefixed by "=~" or a glob pattern, as
+	// "/Users/*/Roles". As a regexp can match a path of any depth,
+	// paths are then tracked during the whole comparison, so prefer
+	// glob patterns.
+	UnorderedSlicePaths []string
+	// FloatMode describes how floats and complex numbers are compared,
+	// at any depth. By default, they are compared exactly. It is also
+	// used by NRel and NULP operators for NaN and -0 handling.
+	FloatMode FloatMode
+	// TimeLocation, if not nil, is the location in which time.Time (or
+	// convertible) values are compared and displayed, at any depth:
+	// two times are then equal if they represent the same instant,
+	// whatever their location and monotonic clock reading are. It is
+	// also used by time operators (as Within or TruncTime) to display
+	// times in failure reports.
+	TimeLocation *time.Location
+	// IgnoreTimeLocation, if true, compares time.Time (or convertible)
+	// values as instants, at any depth, using time.Time.Equal method,
+	// so their location and monotonic clock reading are ignored.
+	IgnoreTimeLocation bool
+
+	// See (*T).RegisterFormatter method
+	formatters formatterSet
+}
+
+// Environment variables used to initialize DefaultContextConfig.
+const (
+	envMaxOutputLen  = "TESTDEEP_MAX_OUTPUT_LEN"
+	envMaxItems      = "TESTDEEP_MAX_ITEMS"
+	envMaxDepth      = "TESTDEEP_MAX_DEPTH"
+	envDumpTruncated = "TESTDEEP_DUMP_TRUNCATED"
+	envExpectedTree  = "TESTDEEP_SHOW_EXPECTED_TREE"
+	envReportIgnored = "TESTDEEP_REPORT_IGNORED_DIFFS"
+)
+
+// DefaultContextConfig is the default configuration used to render
+// tests failures. It is initialized at startup using the following
+// environment variables:
+//   - TESTDEEP_MAX_OUTPUT_LEN for MaxOutputLen field;
+//   - TESTDEEP_MAX_ITEMS for MaxItems field;
+//   - TESTDEEP_MAX_DEPTH for MaxDepth field;
+//   - TESTDEEP_DUMP_TRUNCATED for DumpTruncated field;
+//   - TESTDEEP_SHOW_EXPECTED_TREE for ShowExpectedTree field;
+//   - TESTDEEP_REPORT_IGNORED_DIFFS for ReportIgnoredDiffs field.
+//
+// If one of these variables is unset or invalid, the corresponding
+// field stays at its zero value.
+var DefaultContextConfig = ContextConfig{}
+
+func init() {
+	DefaultContextConfig.MaxOutputLen = getEnvInt(envMaxOutputLen)
+	DefaultContextConfig.MaxItems = getEnvInt(envMaxItems)
+	DefaultContextConfig.MaxDepth = getEnvInt(envMaxDepth)
+	DefaultContextConfig.DumpTruncated = getEnvBool(envDumpTruncated)
+	DefaultContextConfig.ShowExpectedTree = getEnvBool(envExpectedTree)
+	DefaultContextConfig.ReportIgnoredDiffs = getEnvBool(envReportIgnored)
+}
+
+func getEnvInt(name string) int {
+	num, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || num < 0 {
+		return 0
+	}
+	return num
+}
+
+func getEnvBool(name string) bool {
+	b, _ := strconv.ParseBool(os.Getenv(name))
+	return b
+}
+
+// hasLimits returns true if at least one output limit is set.
+func (c *ContextConfig) hasLimits() bool {
+	return c.MaxOutputLen > 0 || c.MaxItems > 0 || c.MaxDepth > 0
+}
+
+// render renders "val" using c formatters, then truncates the result
+// according to c limits.
+func (c *ContextConfig) render(val interface{}) string {
+	return c.limit(toStringWith(c.formatters, val))
+}
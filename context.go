@@ -7,9 +7,8 @@
 package testdeep
 
 import (
-	"fmt"
 	"reflect"
-	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -22,67 +21,223 @@ type visit struct {
 // Context is used internally to keep track of the CmpDeeply in-depth
 // traversal.
 type Context struct {
-	path    string
-	depth   int
+	root  string
+	path  *pathNode // last segment of the path, rendered only if needed
+	depth int
+	// number of path segments appearing in a JSON Pointer, so all but
+	// pointer dereferences
+	jsonDepth int
+	// compiled config.UnorderedSlicePaths, nil if none
+	slicePaths *slicePaths
+	// Allocated only when needed, see deepValueEqual
 	visited map[visit]bool
 	// If true, the contents of the returned *Error will not be
 	// checked. Can be used to avoid filling Error{} with expensive
 	// computations.
 	booleanError bool
+	config       *ContextConfig
+	// TestDeep operators currently matching, the last one being the
+	// innermost. Only filled if config.ShowExpectedTree is true.
+	operators []TestDeep
+	// If true, structs of different types are compared by field names
+	// using byNameTag, whatever config.StructsByName is. See StructLike
+	byName    bool
+	byNameTag string
+	// Differences found in ignored fields, only allocated if
+	// config.ReportIgnoredDiffs is true. See recordIgnored
+	ignoredDiffs *[]string
+	// Shared by all the Contexts of a same cmpDeeply call, nil
+	// otherwise
+	cmp *cmpState
 }
 
-// NewContext creates a new Context using path.
+// cmpState is the state shared by all the Contexts of a same
+// cmpDeeply call.
+type cmpState struct {
+	// true during the boolean pre-pass of cmpDeeply
+	prePass bool
+	// true if the pre-pass has been aborted as a user function was
+	// about to be called, see userCodeAllowed
+	aborted bool
+	// time of the comparison, see now
+	now time.Time
+}
+
+// NewContext creates a new Context using path and
+// DefaultContextConfig.
 func NewContext(path string) Context {
 	return Context{
-		path:    path,
-		visited: map[visit]bool{},
+		root:       path,
+		config:     &DefaultContextConfig,
+		slicePaths: getSlicePaths(DefaultContextConfig.UnorderedSlicePaths),
+	}
+}
+
+// NewContextWithConfig creates a new Context using path and a copy
+// of config.
+func NewContextWithConfig(path string, config ContextConfig) Context {
+	return Context{
+		root:       path,
+		config:     &config,
+		slicePaths: getSlicePaths(config.UnorderedSlicePaths),
 	}
 }
 
 // NewBooleanContext creates a new boolean Context.
 func NewBooleanContext() Context {
 	return Context{
-		visited:      map[visit]bool{},
 		booleanError: true,
+		config:       &DefaultContextConfig,
+		slicePaths:   getSlicePaths(DefaultContextConfig.UnorderedSlicePaths),
 	}
 }
 
-// AddDepth creates a new Context from current one plus pathAdd.
-func (c Context) AddDepth(pathAdd string) (new Context) {
+func (c Context) addSegment(segment PathSegment) (new Context) {
 	new = c
-	if strings.HasPrefix(new.path, "*") {
-		new.path = "(" + new.path + ")" + pathAdd
+	// The path of a boolean Context is never rendered, but can be
+	// needed to take comparison decisions
+	if segment.Kind != PathPtr {
+		new.jsonDepth++
+	}
+	if !c.booleanError || new.keepPath() {
+		new.path = c.path.add(segment)
 	} else {
-		new.path += pathAdd
+		new.path = nil
 	}
 	new.depth++
 	return
 }
 
+// AddDepth creates a new Context from current one plus pathAdd.
+func (c Context) AddDepth(pathAdd string) (new Context) {
+	return c.addSegment(PathSegment{Kind: PathRaw, Name: pathAdd})
+}
+
+// AddField creates a new Context from current one plus a struct
+// field access.
+func (c Context) AddField(name string) (new Context) {
+	return c.addSegment(PathSegment{Kind: PathField, Name: name})
+}
+
 // AddArrayIndex creates a new Context from current one plus an array
 // dereference for index-th item.
 func (c Context) AddArrayIndex(index int) (new Context) {
-	return c.AddDepth(fmt.Sprintf("[%d]", index))
+	return c.addSegment(PathSegment{Kind: PathIndex, Index: index})
+}
+
+// AddMapKey creates a new Context from current one plus a map
+// dereference for key.
+func (c Context) AddMapKey(key reflect.Value) (new Context) {
+	return c.addSegment(PathSegment{Kind: PathMapKey, key: key})
 }
 
 // AddPtr creates a new Context from current one plus a pointer dereference.
 func (c Context) AddPtr(num int) (new Context) {
-	new = c
-	new.path = strings.Repeat("*", num) + new.path
-	new.depth++
-	return
+	return c.addSegment(PathSegment{Kind: PathPtr, Index: num})
 }
 
 // AddFunctionCall creates a new Context from current one inside a
 // function call.
 func (c Context) AddFunctionCall(fn string) (new Context) {
+	return c.addSegment(PathSegment{Kind: PathFunctionCall, Name: fn})
+}
+
+// addCaptures creates a new Context from current one for the
+// captures of regexp re applied to current path. The depth is not
+// incremented.
+func (c Context) addCaptures(re string) (new Context) {
 	new = c
-	new.path = fn + "(" + new.path + ")"
-	new.depth++
+	if !c.booleanError {
+		new.path = c.path.add(PathSegment{Kind: PathCaptures, Name: re})
+	}
 	return
 }
 
-// Path returns the Context path.
+// Path returns the Context path, rendered as a string.
 func (c Context) Path() string {
-	return c.path
+	return c.Segments().String()
+}
+
+// Segments returns the Context path as a list of segments.
+func (c Context) Segments() Path {
+	return c.path.path(c.root)
+}
+
+// getConfig returns the ContextConfig of c, or DefaultContextConfig
+// if c has not been created by one of the NewContext* functions.
+func (c Context) getConfig() *ContextConfig {
+	if c.config == nil {
+		return &DefaultContextConfig
+	}
+	return c.config
+}
+
+// structsByName returns the struct tag to use and true if structs of
+// different types have to be compared field by field, by name.
+func (c Context) structsByName() (string, bool) {
+	if c.byName {
+		return c.byNameTag, true
+	}
+	config := c.getConfig()
+	return config.StructsByNameTag, config.StructsByName
+}
+
+// userCodeAllowed has to be called by operators just before calling
+// a user function. If c belongs to the boolean pre-pass of cmpDeeply,
+// this pre-pass is aborted and false is returned. The caller then has
+// to return booleanError without calling the user function, that
+// will be called during the following full pass only.
+func (c Context) userCodeAllowed() bool {
+	if c.cmp != nil && c.cmp.prePass {
+		c.cmp.aborted = true
+		return false
+	}
+	return true
+}
+
+// now returns the time of the comparison, captured once per
+// cmpDeeply call so both its passes take the same decisions.
+func (c Context) now() time.Time {
+	if c.cmp == nil {
+		return time.Now()
+	}
+	if c.cmp.now.IsZero() {
+		c.cmp.now = time.Now()
+	}
+	return c.cmp.now
+}
+
+// aborted returns true if c belongs to an aborted pre-pass of
+// cmpDeeply, see userCodeAllowed.
+func (c Context) aborted() bool {
+	return c.cmp != nil && c.cmp.aborted
+}
+
+// boolean returns a boolean Context from current one, keeping its
+// comparison settings.
+func (c Context) boolean() Context {
+	c.booleanError = true
+	if !c.keepPath() {
+		c.path = nil
+	}
+	c.operators = nil
+	c.ignoredDiffs = nil
+	return c
+}
+
+// enterOperator returns a new Context from current one, recording
+// that "op" TestDeep operator is matching, but only if the operators
+// tree has to be displayed in case of failure.
+func (c Context) enterOperator(op TestDeep) Context {
+	if c.booleanError || !c.getConfig().ShowExpectedTree {
+		return c
+	}
+
+	// Always copy, as c.operators can be shared by other Contexts
+	operators := make([]TestDeep, len(c.operators)+1)
+	copy(operators, c.operators)
+	operators[len(c.operators)] = op
+
+	c.operators = operators
+	return c
 }
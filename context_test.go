@@ -7,6 +7,7 @@
 package testdeep
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -23,20 +24,78 @@ func equalStr(t *testing.T, got, expected string) bool {
 }
 
 func TestContext(t *testing.T) {
-	equalStr(t, NewContext("test").path, "test")
-	equalStr(t, NewBooleanContext().path, "")
+	equalStr(t, NewContext("test").Path(), "test")
+	equalStr(t, NewBooleanContext().Path(), "")
 
-	equalStr(t, NewContext("test").AddDepth(".foo").path, "test.foo")
+	equalStr(t, NewContext("test").AddDepth(".foo").Path(), "test.foo")
 
-	equalStr(t, NewContext("test").AddDepth(".foo").AddDepth(".bar").path,
+	equalStr(t, NewContext("test").AddDepth(".foo").AddDepth(".bar").Path(),
 		"test.foo.bar")
 
-	equalStr(t, NewContext("*test").AddDepth(".foo").path, "(*test).foo")
+	equalStr(t, NewContext("*test").AddDepth(".foo").Path(), "(*test).foo")
 
-	equalStr(t, NewContext("test").AddArrayIndex(12).path, "test[12]")
-	equalStr(t, NewContext("*test").AddArrayIndex(12).path, "(*test)[12]")
+	equalStr(t, NewContext("test").AddArrayIndex(12).Path(), "test[12]")
+	equalStr(t, NewContext("*test").AddArrayIndex(12).Path(), "(*test)[12]")
 
-	equalStr(t, NewContext("test").AddPtr(2).path, "**test")
-	equalStr(t, NewContext("test.foo").AddPtr(1).path, "*test.foo")
-	equalStr(t, NewContext("test[3]").AddPtr(1).path, "*test[3]")
+	equalStr(t, NewContext("test").AddPtr(2).Path(), "**test")
+	equalStr(t, NewContext("test.foo").AddPtr(1).Path(), "*test.foo")
+	equalStr(t, NewContext("test[3]").AddPtr(1).Path(), "*test[3]")
+
+	equalStr(t, NewContext("test").AddField("foo").Path(), "test.foo")
+	equalStr(t, NewContext("test").AddPtr(1).AddField("foo").Path(),
+		"(*test).foo")
+	equalStr(t,
+		NewContext("test").AddMapKey(reflect.ValueOf("foo")).Path(),
+		`test[(string) (len=3) "foo"]`)
+	equalStr(t, NewContext("test").AddFunctionCall("len").Path(), "len(test)")
+	equalStr(t, NewContext("test").addCaptures("^a(b)").Path(),
+		"(test =~ ^a(b))")
+}
+
+func TestContextSegments(t *testing.T) {
+	ctx := NewContext("DATA").
+		AddField("Foo").
+		AddPtr(1).
+		AddMapKey(reflect.ValueOf("a/b~c'd")).
+		AddArrayIndex(3).
+		AddMapKey(reflect.ValueOf(42)).
+		AddFunctionCall("len")
+
+	path := ctx.Segments()
+	equalStr(t, path.Root, "DATA")
+	if len(path.Segments) != 6 {
+		t.Fatalf("6 segments expected, got %d", len(path.Segments))
+	}
+
+	for idx, kind := range []PathSegmentKind{
+		PathField, PathPtr, PathMapKey, PathIndex, PathMapKey, PathFunctionCall,
+	} {
+		if path.Segments[idx].Kind != kind {
+			t.Errorf("segment #%d: kind %s expected, got %s",
+				idx, kind, path.Segments[idx].Kind)
+		}
+	}
+	if path.Segments[2].Key() != "a/b~c'd" {
+		t.Errorf("bad key for segment #2: %v", path.Segments[2].Key())
+	}
+	if path.Segments[0].Key() != nil {
+		t.Errorf("no key expected for segment #0: %v", path.Segments[0].Key())
+	}
+
+	equalStr(t, path.String(), ctx.Path())
+	equalStr(t, path.String(),
+		`len((*DATA.Foo)[(string) (len=7) "a/b~c'd"][3][(int) 42])`)
+	equalStr(t, path.JSONPointer(), "/Foo/a~1b~0c'd/3/42/len()")
+	equalStr(t, path.JSONPath(), `$.Foo['a/b~c\'d'][3][42].len()`)
+
+	path = NewContext("DATA").
+		AddDepth("<All#1/2>").
+		addCaptures("^(a)").
+		Segments()
+	equalStr(t, path.String(), "(DATA<All#1/2> =~ ^(a))")
+	equalStr(t, path.JSONPointer(), "/<All#1~12>/captures(^(a))")
+	equalStr(t, path.JSONPath(), "$<All#1/2>.captures('^(a)')")
+
+	equalStr(t, NewContext("DATA").Segments().JSONPointer(), "")
+	equalStr(t, NewContext("DATA").Segments().JSONPath(), "$")
 }
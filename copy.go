@@ -8,8 +8,6 @@ package testdeep
 
 import (
 	"reflect"
-	"unicode"
-	"unicode/utf8"
 )
 
 // CopyValue does its best to copy val in a new reflect.Value instance.
@@ -135,31 +133,25 @@ func CopyValue(val reflect.Value) (reflect.Value, bool) {
 
 	case reflect.Struct:
 		// First, check if all fields are public
-		sType := val.Type()
-		for i, n := 0, val.NumField(); i < n; i++ {
-			r, _ := utf8.DecodeRuneInString(sType.Field(i).Name)
-			if !unicode.IsUpper(r) {
-				return reflect.Value{}, false
-			}
+		sInfo := getTypeInfo(val.Type())
+		if !sInfo.allExported {
+			return reflect.Value{}, false
 		}
 
 		// OK all fields are public
-		newPtrVal := reflect.New(sType)
+		newPtrVal := reflect.New(sInfo.typ)
 		newVal = newPtrVal.Elem()
 
 		var (
-			fieldIdx []int
 			fieldVal reflect.Value
 			ok       bool
 		)
-		for i, n := 0, val.NumField(); i < n; i++ {
-			fieldIdx = sType.Field(i).Index
-
-			fieldVal, ok = CopyValue(val.FieldByIndex(fieldIdx))
+		for i := range sInfo.fields {
+			fieldVal, ok = CopyValue(val.Field(i))
 			if !ok {
 				return reflect.Value{}, false // Should not happen as already checked
 			}
-			newVal.FieldByIndex(fieldIdx).Set(fieldVal)
+			newVal.Field(i).Set(fieldVal)
 		}
 
 		// Does not handle Chan, Func and UnsafePointer
@@ -24,7 +24,85 @@ func isNilStr(isNil bool) rawString {
 	return "not nil"
 }
 
+// cyclesDetectionDepth is the depth from which visited values are
+// recorded to detect cycles. Below it, nothing needs to be allocated.
+const cyclesDetectionDepth = 16
+
+// equalScalars returns true if "got" equals "expected", both having
+// the same type of a kind not handled specifically by
+// deepValueEqual. Floats and complex numbers are compared according
+// to "floats". Values are not boxed in interfaces, so nothing is
+// allocated.
+func equalScalars(got, expected reflect.Value, floats *FloatMode) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == expected.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == expected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == expected.Uint()
+	case reflect.Float32, reflect.Float64:
+		return floats.equal(got.Float(), expected.Float(), got.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		return floats.equalComplex(got.Complex(), expected.Complex(),
+			got.Type().Bits())
+	case reflect.String:
+		return got.String() == expected.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return got.Pointer() == expected.Pointer()
+	default:
+		gotIf, gotOK := getInterface(got, true)
+		expectedIf, expectedOK := getInterface(expected, true)
+		return gotOK && expectedOK && gotIf == expectedIf
+	}
+}
+
+// scalarKey is a map key identifying a value of bool, integer or
+// string kind. Two such values are equal for deepValueEqual if and
+// only if their scalarKeys are equal, so they can be grouped through
+// a map instead of being compared one to each other.
+type scalarKey struct {
+	typ reflect.Type
+	num uint64
+	str string
+}
+
+// newScalarKey returns the scalarKey of "val" and true, or false if
+// "val" can only be compared using deepValueEqual.
+func newScalarKey(val reflect.Value) (key scalarKey, ok bool) {
+	if !val.IsValid() {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Bool:
+		if val.Bool() {
+			key.num = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		key.num = uint64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		key.num = val.Uint()
+	case reflect.String:
+		key.str = val.String()
+	default:
+		return
+	}
+
+	key.typ = val.Type()
+	if key.typ.Implements(testDeeper) {
+		return scalarKey{}, false
+	}
+	return key, true
+}
+
 func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
+	if ctx.aborted() {
+		return booleanError
+	}
+
 	if !got.IsValid() || !expected.IsValid() {
 		if got.IsValid() == expected.IsValid() {
 			return
@@ -36,7 +114,7 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 			if expected.Type().Implements(testDeeper) {
 				td := expected.Interface().(TestDeep)
 				if td.HandleInvalid() {
-					return td.Match(ctx, got)
+					return td.Match(ctx.enterOperator(td), got)
 				}
 				if ctx.booleanError {
 					return booleanError
@@ -46,6 +124,7 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 				// does not handle invalid values: the operator is not called,
 				// but for the user the error comes from it
 				err.Location = td.GetLocation()
+				ctx = ctx.enterOperator(td)
 			} else if ctx.booleanError {
 				return booleanError
 			}
@@ -78,7 +157,8 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 
 	if got.Type() != expected.Type() {
 		if expected.Type().Implements(testDeeper) {
-			return expected.Interface().(TestDeep).Match(ctx, got)
+			td := expected.Interface().(TestDeep)
+			return td.Match(ctx.enterOperator(td), got)
 		}
 
 		// "expected" is not a TestDeep operator
@@ -91,6 +171,11 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 			return deepValueEqual(ctx, got.Elem(), expected)
 		}
 
+		if tag, ok := ctx.structsByName(); ok &&
+			canCompareByName(got.Type(), expected.Type()) {
+			return deepValueEqualByName(ctx, got, expected, tag)
+		}
+
 		if ctx.booleanError {
 			return booleanError
 		}
@@ -115,7 +200,8 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 		return false
 	}
 
-	if got.CanAddr() && expected.CanAddr() && hard(got.Kind()) {
+	if ctx.depth >= cyclesDetectionDepth &&
+		got.CanAddr() && expected.CanAddr() && hard(got.Kind()) {
 		addr1 := unsafe.Pointer(got.UnsafeAddr())
 		addr2 := unsafe.Pointer(expected.UnsafeAddr())
 		if uintptr(addr1) > uintptr(addr2) {
@@ -134,7 +220,12 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 			return
 		}
 
-		// Remember for later.
+		// Remember for later. The map is allocated the first time it is
+		// needed, then shared by all the sub-Contexts of ctx, which is
+		// enough to detect cycles
+		if ctx.visited == nil {
+			ctx.visited = map[visit]bool{}
+		}
 		ctx.visited[v] = true
 	}
 
@@ -175,6 +266,9 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 		if got.Pointer() == expected.Pointer() {
 			return
 		}
+		if ctx.unorderedSlice(got.Type()) {
+			return deepValueEqualUnordered(ctx, got, expected)
+		}
 		for i := 0; i < got.Len(); i++ {
 			err = deepValueEqual(ctx.AddArrayIndex(i),
 				got.Index(i), expected.Index(i))
@@ -208,9 +302,19 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 		return deepValueEqual(ctx.AddPtr(1), got.Elem(), expected.Elem())
 
 	case reflect.Struct:
-		sType := got.Type()
-		for i, n := 0, got.NumField(); i < n; i++ {
-			err = deepValueEqual(ctx.AddDepth("."+sType.Field(i).Name),
+		if ctx.timeAsInstant(got.Type()) {
+			return deepValueEqualTime(ctx, got, expected)
+		}
+		fields := getTypeInfo(got.Type()).fields
+		for i := range fields {
+			field := &fields[i]
+			if reason, ignored := ctx.ignoreField(field); ignored {
+				ctx.AddField(field.name).recordIgnored(reason,
+					got.Field(i), expected.Field(i))
+				continue
+			}
+
+			err = deepValueEqual(ctx.AddField(field.name),
 				got.Field(i), expected.Field(i))
 			if err != nil {
 				return
@@ -235,8 +339,10 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 			return
 		}
 
-		var notFoundKeys []reflect.Value
-		foundKeys := map[interface{}]bool{}
+		var (
+			notFoundKeys []reflect.Value
+			numFoundKeys int
+		)
 
 		for _, vkey := range expected.MapKeys() {
 			gotValue := got.MapIndex(vkey)
@@ -245,15 +351,15 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 				continue
 			}
 
-			err = deepValueEqual(ctx.AddDepth("["+toString(vkey)+"]"),
+			err = deepValueEqual(ctx.AddMapKey(vkey),
 				gotValue, expected.MapIndex(vkey))
 			if err != nil {
 				return
 			}
-			foundKeys[mustGetInterface(vkey)] = true
+			numFoundKeys++
 		}
 
-		if got.Len() == len(foundKeys) {
+		if got.Len() == numFoundKeys {
 			if len(notFoundKeys) == 0 {
 				return
 			}
@@ -279,11 +385,11 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 		res := tdSetResult{
 			Kind:    keysSetResult,
 			Missing: notFoundKeys,
-			Extra:   make([]reflect.Value, 0, got.Len()-len(foundKeys)),
+			Extra:   make([]reflect.Value, 0, got.Len()-numFoundKeys),
 		}
 
 		for _, vkey := range got.MapKeys() {
-			if !foundKeys[vkey.Interface()] {
+			if !expected.MapIndex(vkey).IsValid() {
 				res.Extra = append(res.Extra, vkey)
 			}
 		}
@@ -310,7 +416,7 @@ func deepValueEqual(ctx Context, got, expected reflect.Value) (err *Error) {
 
 	default:
 		// Normal equality suffices
-		if mustGetInterface(got) == mustGetInterface(expected) {
+		if equalScalars(got, expected, &ctx.getConfig().FloatMode) {
 			return
 		}
 		if ctx.booleanError {
@@ -356,14 +462,6 @@ func getInterface(val reflect.Value, force bool) (interface{}, bool) {
 	return nil, false
 }
 
-func mustGetInterface(val reflect.Value) interface{} {
-	ret, ok := getInterface(val, true)
-	if ok {
-		return ret
-	}
-	panic("getInterface() does not handle " + val.Kind().String() + " kind")
-}
-
 // EqDeeply returns true if "got" matches "expected". "expected" can
 // be the same type as "got" is, or contains some TestDeep operators.
 func EqDeeply(got, expected interface{}) bool {
@@ -392,7 +490,32 @@ func EqDeeplyError(got, expected interface{}) *Error {
 // parameters. See fmt.Sprintf for details.
 func CmpDeeply(t *testing.T, got, expected interface{},
 	args ...interface{}) bool {
-	err := EqDeeplyError(got, expected)
+	t.Helper()
+	return cmpDeeply(NewContext("DATA"), t, got, expected, args...)
+}
+
+func cmpDeeply(ctx Context, t *testing.T, got, expected interface{},
+	args ...interface{}) bool {
+	vgot, vexpected := reflect.ValueOf(got), reflect.ValueOf(expected)
+
+	// First check in a boolean Context, so nothing is allocated to
+	// build the error when "got" matches "expected". This pre-pass is
+	// aborted as soon as a user function (as the one of Code operator)
+	// is about to be called, so it is only called once, by the full
+	// pass following
+	state := &cmpState{prePass: true}
+	ctx.cmp = state
+	if deepValueEqual(ctx.boolean(), vgot, vexpected) == nil && !state.aborted {
+		return true
+	}
+	state.prePass, state.aborted = false, false
+
+	config := ctx.getConfig()
+	if config.ReportIgnoredDiffs {
+		ctx.ignoredDiffs = new([]string)
+	}
+
+	err := deepValueEqual(ctx, vgot, vexpected)
 	if err == nil {
 		return true
 	}
@@ -411,6 +534,17 @@ func CmpDeeply(t *testing.T, got, expected interface{},
 		label = fmt.Sprintf(failedTest+" '"+args[0].(string)+"'\n", args[1:]...)
 	}
 
-	t.Error(label + err.Error())
+	msg := label + err.Error()
+	if ctx.ignoredDiffs != nil && len(*ctx.ignoredDiffs) > 0 {
+		msg += "\nDifferences in ignored fields:"
+		for _, diff := range *ctx.ignoredDiffs {
+			msg += "\n\t" + diff
+		}
+	}
+	if config.ShowExpectedTree {
+		msg += expectedTree(vexpected, err)
+	}
+
+	t.Error(msg)
 	return false
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+type benchItem struct {
+	ID     int
+	Name   string
+	Tags   []string
+	Values map[string]int
+	Next   *benchItem
+}
+
+func benchData() []benchItem {
+	items := make([]benchItem, 100)
+	for i := range items {
+		items[i] = benchItem{
+			ID:     i,
+			Name:   "item",
+			Tags:   []string{"a", "b", "c"},
+			Values: map[string]int{"x": i, "y": i * 2},
+			Next:   &benchItem{ID: -i},
+		}
+	}
+	return items
+}
+
+func BenchmarkEqDeeply(b *testing.B) {
+	got, expected := benchData(), benchData()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !EqDeeply(got, expected) {
+			b.Fatal("EqDeeply failed")
+		}
+	}
+}
+
+func BenchmarkEqDeeplyOperators(b *testing.B) {
+	got := benchData()
+	expected := make([]interface{}, len(got))
+	for i := range expected {
+		expected[i] = Struct(benchItem{}, StructFields{
+			"ID":   Between(0, 1000),
+			"Name": All(Len(4), HasPrefix("it")),
+			"Tags": ArrayEach(Any("a", "b", "c")),
+		})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for idx := range got {
+			if !EqDeeply(got[idx], expected[idx]) {
+				b.Fatal("EqDeeply failed")
+			}
+		}
+	}
+}
+
+func BenchmarkCmpDeeply(b *testing.B) {
+	got, expected := benchData(), benchData()
+	t := &testing.T{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !CmpDeeply(t, got, expected) {
+			b.Fatal("CmpDeeply failed")
+		}
+	}
+}
+
+func BenchmarkStruct(b *testing.B) {
+	got := benchData()[0]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if !EqDeeply(got, Struct(benchItem{}, StructFields{
+			"ID":   0,
+			"Name": "item",
+			"Tags": []string{"a", "b", "c"},
+		})) {
+			b.Fatal("EqDeeply failed")
+		}
+	}
+}
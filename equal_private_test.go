@@ -62,3 +62,46 @@ func TestGetInterface(t *testing.T) {
 	//	t.Error("panic() did not occur")
 	//}
 }
+
+func TestScalarKey(t *testing.T) {
+	type myInt int
+
+	key := func(v interface{}) (scalarKey, bool) {
+		return newScalarKey(reflect.ValueOf(v))
+	}
+
+	for _, pair := range [][2]interface{}{
+		{true, true},
+		{-1, -1},
+		{uint8(200), uint8(200)},
+		{"foo", "foo"},
+	} {
+		k1, ok1 := key(pair[0])
+		k2, ok2 := key(pair[1])
+		if !ok1 || !ok2 || k1 != k2 {
+			t.Errorf("%v and %v should have the same key", pair[0], pair[1])
+		}
+	}
+
+	for _, pair := range [][2]interface{}{
+		{true, false},
+		{1, 2},
+		{1, myInt(1)},
+		{1, int64(1)},
+		{-1, ^uint(0)},
+		{"foo", "bar"},
+	} {
+		k1, _ := key(pair[0])
+		k2, _ := key(pair[1])
+		if k1 == k2 {
+			t.Errorf("%v (%[1]T) and %v (%[2]T) should have different keys",
+				pair[0], pair[1])
+		}
+	}
+
+	for _, v := range []interface{}{nil, 1.5, []int{}, &struct{}{}, Ignore()} {
+		if _, ok := key(v); ok {
+			t.Errorf("%v (%[1]T) should have no key", v)
+		}
+	}
+}
@@ -40,12 +40,13 @@ func (e *Error) Error() string {
 
 	buf := &bytes.Buffer{}
 
+	path := e.Context.Path()
 	if pos := strings.Index(e.Message, "%%"); pos >= 0 {
 		buf.WriteString(e.Message[:pos])
-		buf.WriteString(e.Context.path)
+		buf.WriteString(path)
 		buf.WriteString(e.Message[pos+2:])
 	} else {
-		buf.WriteString(e.Context.path)
+		buf.WriteString(path)
 		buf.WriteString(": ")
 		buf.WriteString(e.Message)
 	}
@@ -82,32 +83,42 @@ func (e *Error) Error() string {
 }
 
 // GotString returns the string corresponding to the Got
-// field. Returns the empty string if the Error Summary field is not
-// empty.
+// field, rendered and truncated according to the Context
+// configuration (see ContextConfig). Returns the empty string if the Error Summary
+// field is not empty.
 func (e *Error) GotString() string {
 	if e.Summary != nil {
 		return ""
 	}
-	return toString(e.Got)
+	return e.Context.getConfig().render(e.Got)
 }
 
 // ExpectedString returns the string corresponding to the Expected
-// field. Returns the empty string if the Error Summary field is not
-// empty.
+// field, rendered and truncated according to the Context
+// configuration (see ContextConfig). Returns the empty string if the Error Summary
+// field is not empty.
 func (e *Error) ExpectedString() string {
 	if e.Summary != nil {
 		return ""
 	}
-	return toString(e.Expected)
+	return e.Context.getConfig().render(e.Expected)
 }
 
 // SummaryString returns the string corresponding to the Summary
-// field. Returns the empty string if the Error Summary field is nil.
+// field, rendered and truncated according to the Context
+// configuration (see ContextConfig). Returns the empty string if the Error Summary
+// field is nil.
 func (e *Error) SummaryString() string {
 	if e.Summary == nil {
 		return ""
 	}
-	return toString(e.Summary)
+	return e.Context.getConfig().render(e.Summary)
+}
+
+// Path returns the path of the Error as a list of segments. Use its
+// String, JSONPointer or JSONPath methods to render it.
+func (e *Error) Path() Path {
+	return e.Context.Segments()
 }
 
 // SetLocationIfMissing initializes the Error Location field if it not
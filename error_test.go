@@ -88,3 +88,50 @@ Originates from following error:
 		(int) 42
 	[under TestDeep operator SubOperator at file2.go:236]`)
 }
+
+func TestErrorLimits(t *testing.T) {
+	err := Error{
+		Context: NewContextWithConfig("DATA", ContextConfig{
+			MaxItems: 2,
+		}),
+		Message:  "Error message",
+		Got:      []int{1, 2, 3, 4, 5},
+		Expected: []int{1, 2},
+	}
+	equalStr(t, err.Error(),
+		`DATA: Error message
+	     got: ([]int) (len=5 cap=5) {
+	           (int) 1,
+	           (int) 2,
+	           …(3 more items)
+	          }
+	expected: ([]int) (len=2 cap=2) {
+	           (int) 1,
+	           (int) 2
+	          }`)
+
+	err = Error{
+		Context: NewContextWithConfig("DATA", ContextConfig{
+			MaxOutputLen: 8,
+		}),
+		Message: "Error message",
+		Summary: "0123456789",
+	}
+	equalStr(t, err.Error(),
+		`DATA: Error message
+	(string)…(22 more bytes)`)
+}
+
+func TestErrorPath(t *testing.T) {
+	err := EqDeeplyError(
+		map[string][]int{"foo": {1, 2}},
+		map[string][]int{"foo": {1, 3}})
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+
+	path := err.Path()
+	equalStr(t, path.String(), `DATA[(string) (len=3) "foo"][1]`)
+	equalStr(t, path.JSONPointer(), "/foo/1")
+	equalStr(t, path.JSONPath(), "$['foo'][1]")
+}
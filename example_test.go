@@ -10,8 +10,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -135,6 +138,23 @@ func ExampleIgnore() {
 	// true
 }
 
+func ExampleAfter() {
+	t := &testing.T{}
+
+	start := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := start.Add(time.Minute)
+
+	ok := CmpDeeply(t, got, After(start), "checks %v is after %v", got, start)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, start, After(got), "checks %v is after %v", start, got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleAll() {
 	t := &testing.T{}
 
@@ -282,6 +302,32 @@ func ExampleArrayEach_typedSlice() {
 	// true
 }
 
+func ExampleAtLeast() {
+	t := &testing.T{}
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := CmpDeeply(t, got, AtLeast(Gt(10), 2),
+		"checks at least 2 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleAtMost() {
+	t := &testing.T{}
+
+	got := map[string]int{"a": 12, "b": 3, "c": 20}
+
+	ok := CmpDeeply(t, got, AtMost(Lt(10), 1),
+		"checks at most 1 value of %v is < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleBag() {
 	t := &testing.T{}
 
@@ -317,6 +363,65 @@ func ExampleBag() {
 	// true
 }
 
+func ExampleBagBy() {
+	t := &testing.T{}
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 3, Name: "Charlie"},
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+	charlie := Record{ID: 3, Name: "Charlie"}
+
+	// Matches as all records are present, in any order
+	ok := CmpDeeply(t, got, BagBy("ID", alice, bob, charlie),
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #3 is not expected
+	ok = CmpDeeply(t, got, BagBy("ID", alice, bob),
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #2 differs
+	bobby := Record{ID: 2, Name: "Bobby"}
+	ok = CmpDeeply(t, got, BagBy("ID", alice, bobby, charlie),
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
+func ExampleBefore() {
+	t := &testing.T{}
+
+	deadline := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := deadline.Add(-time.Minute)
+
+	ok := CmpDeeply(t, got, Before(deadline),
+		"checks %v is before %v", got, deadline)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, deadline, Before(deadline),
+		"checks %v is before %v", deadline, deadline)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleBetween() {
 	t := &testing.T{}
 
@@ -351,6 +456,32 @@ func ExampleBetween() {
 	// false
 }
 
+func ExampleBetween_ordered() {
+	t := &testing.T{}
+
+	// Strings are compared lexically
+	ok := CmpDeeply(t, "abc", Between("abb", "abd"),
+		`checks "abc" is in ["abb" .. "abd"]`)
+	fmt.Println(ok)
+
+	// Integers of different sizes can be compared
+	got := int64(156)
+	ok = CmpDeeply(t, got, Between(154, 156),
+		"checks %v is in [154 .. 156]", got)
+	fmt.Println(ok)
+
+	// Types with a Cmp method, as *big.Int
+	gotBig := big.NewInt(156)
+	ok = CmpDeeply(t, gotBig, Between(big.NewInt(154), big.NewInt(156)),
+		"checks %v is in [154 .. 156]", gotBig)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// true
+}
+
 func ExampleCap() {
 	t := &testing.T{}
 
@@ -409,6 +540,48 @@ func ExampleCode() {
 	// true
 }
 
+func ExampleCount() {
+	t := &testing.T{}
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := CmpDeeply(t, got, Count(Gt(10), 3),
+		"checks 3 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Count(Lt(10), Between(1, 3)),
+		"checks 1 to 3 items of %v are < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
+func ExampleFinite() {
+	t := &testing.T{}
+
+	got := 12.5
+
+	ok := CmpDeeply(t, got, Finite(), "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.Inf(1)
+
+	ok = CmpDeeply(t, got, Finite(), "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = CmpDeeply(t, got, Finite(), "checks %v is finite", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
 func ExampleGt() {
 	t := &testing.T{}
 
@@ -437,6 +610,26 @@ func ExampleGte() {
 	// true
 }
 
+func ExampleInf() {
+	t := &testing.T{}
+
+	got := math.Inf(-1)
+
+	ok := CmpDeeply(t, got, Inf(-1), "checks %v is -Inf", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Inf(0), "checks %v is an infinity", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Inf(1), "checks %v is +Inf", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleIsa() {
 	t := &testing.T{}
 
@@ -673,6 +866,59 @@ func ExampleMapEach_typedMap() {
 	// true
 }
 
+func ExampleMapEachKey() {
+	t := &testing.T{}
+
+	got := map[string]int{"id_1": 12, "id_2": 42, "id_3": 89}
+
+	ok := CmpDeeply(t, got, MapEachKey(Re(`^id_\d+$`)),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	got["id_x"] = 0
+	ok = CmpDeeply(t, got, MapEachKey(Re(`^id_\d+$`)),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleMapEachEntry() {
+	t := &testing.T{}
+
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	got := map[int]User{
+		1: {ID: 1, Name: "Alice"},
+		2: {ID: 2, Name: "Bob"},
+	}
+
+	ok := CmpDeeply(t, got,
+		MapEachEntry(func(id int, user User) bool { return user.ID == id }),
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	got[3] = User{ID: 4, Name: "Charlie"}
+	ok = CmpDeeply(t, got,
+		MapEachEntry(func(id int, user User) (bool, string) {
+			if user.ID != id {
+				return false, "ID field must be the same as the key"
+			}
+			return true, ""
+		}),
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleN() {
 	t := &testing.T{}
 
@@ -686,6 +932,23 @@ func ExampleN() {
 	// true
 }
 
+func ExampleNaN() {
+	t := &testing.T{}
+
+	got := math.NaN()
+
+	ok := CmpDeeply(t, got, NaN(), "checks %v is NaN", got)
+	fmt.Println(ok)
+
+	// NaN is not equal to NaN, even using NaN value
+	ok = CmpDeeply(t, got, math.NaN())
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleNil() {
 	t := &testing.T{}
 
@@ -785,6 +1048,24 @@ func ExampleNot() {
 	// false
 }
 
+func ExampleNotNaN() {
+	t := &testing.T{}
+
+	got := 12.5
+
+	ok := CmpDeeply(t, got, NotNaN(), "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = CmpDeeply(t, got, NotNaN(), "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleNotNil() {
 	t := &testing.T{}
 
@@ -816,6 +1097,45 @@ func ExampleNotNil() {
 	// false
 }
 
+func ExampleNRel() {
+	t := &testing.T{}
+
+	got := 1000.0000001
+
+	ok := CmpDeeply(t, got, NRel(1000.0, 1e-9),
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	got = 1000.001
+
+	ok = CmpDeeply(t, got, NRel(1000.0, 1e-9),
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleNULP() {
+	t := &testing.T{}
+
+	a, b := 0.1, 0.2
+	got := a + b
+
+	ok := CmpDeeply(t, got, NULP(0.3, 1),
+		"checks %v = 0.3 ± 1 ULP", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, NULP(0.3, 0),
+		"checks %v = 0.3 exactly", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExamplePPtr() {
 	t := &testing.T{}
 
@@ -1065,6 +1385,48 @@ func ExampleReAll_compiledCaptureComplex() {
 	// false
 }
 
+func ExampleRecent() {
+	t := &testing.T{}
+
+	// Typically the creation date of a freshly created record
+	got := time.Now()
+
+	ok := CmpDeeply(t, got, Recent(time.Second),
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	got = got.Add(-time.Hour)
+
+	ok = CmpDeeply(t, got, Recent(time.Second),
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleRoundTime() {
+	t := &testing.T{}
+
+	got := time.Date(2018, time.May, 1, 12, 45, 53, 600000000, time.UTC)
+
+	// Compare dates rounded to the second
+	expected := time.Date(2018, time.May, 1, 12, 45, 54, 0, time.UTC)
+	ok := CmpDeeply(t, got, RoundTime(expected, time.Second),
+		"checks date %v, rounded to the second", got)
+	fmt.Println(ok)
+
+	// Truncation does not give the same result
+	ok = CmpDeeply(t, got, TruncTime(expected, time.Second),
+		"checks date %v, truncated to the second", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleSet() {
 	t := &testing.T{}
 
@@ -1092,6 +1454,39 @@ func ExampleSet() {
 	// true
 }
 
+func ExampleSetBy() {
+	t := &testing.T{}
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 1, Name: "Alice"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+
+	// Matches as all records are present, ignoring duplicates
+	ok := CmpDeeply(t, got, SetBy("ID", alice, bob),
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #1 differs
+	alicia := Record{ID: 1, Name: "Alicia"}
+	ok = CmpDeeply(t, got, SetBy("ID", alicia, bob),
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleShallow() {
 	t := &testing.T{}
 
@@ -1148,6 +1543,105 @@ func ExampleSlice_typedSlice() {
 	// true
 }
 
+func ExampleSlice_negativeIndexes() {
+	t := &testing.T{}
+
+	got := []int{42, 58, 26, 12, 7}
+
+	ok := CmpDeeply(t, got, Slice([]int{42},
+		ArrayEntries{-1: 7},
+		ArrayRanges{ArrayRange{1, -2}: Gte(12)}),
+		"checks first & last items of slice %v, others being >= 12", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleSliceHasPrefix() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := CmpDeeply(t, got, SliceHasPrefix("start", "open"),
+		"checks %v starts with start & open", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, SliceHasPrefix("start", Not("open")),
+		"checks %v second item is not open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleSliceHasSuffix() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := CmpDeeply(t, got, SliceHasSuffix("close", "stop"),
+		"checks %v ends with close & stop", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleSorted() {
+	t := &testing.T{}
+
+	got := []int{1, 3, 3, 7}
+
+	ok := CmpDeeply(t, got, Sorted(), "checks %v is sorted", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Sorted(-1), "checks %v is sorted in descending order", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleSorted_fields() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := []Person{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 42},
+		{Name: "Bob", Age: 20},
+	}
+
+	ok := CmpDeeply(t, got, Sorted("Name", "-Age"),
+		"checks persons are sorted by name, then by age in descending order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleSorted_lessFunc() {
+	t := &testing.T{}
+
+	got := []string{"c", "ab", "xyz"}
+
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+
+	ok := CmpDeeply(t, got, Sorted(byLen),
+		"checks %v is sorted by string length", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleString() {
 	t := &testing.T{}
 
@@ -1345,6 +1839,100 @@ func ExampleStruct() {
 	// true
 }
 
+func ExampleSStruct() {
+	t := &testing.T{}
+
+	type Person struct {
+		Name        string
+		Age         int
+		NumChildren int
+	}
+
+	got := Person{
+		Name:        "Foobar",
+		Age:         42,
+		NumChildren: 0,
+	}
+
+	// NumChildren is zero in SStruct() call, so it is checked too
+	ok := CmpDeeply(t, got,
+		SStruct(Person{Name: "Foobar"}, StructFields{
+			"Age": Between(40, 50),
+		}),
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Model can be empty
+	ok = CmpDeeply(t, got,
+		SStruct(Person{}, StructFields{
+			"Name": "Foobar",
+			"Age":  Between(40, 50),
+		}),
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// NumChildren is not zero, but absent from SStruct() call
+	got.NumChildren = 3
+	ok = CmpDeeply(t, &got,
+		SStruct(&Person{}, StructFields{
+			"Name": "Foobar",
+			"Age":  Between(40, 50),
+		}),
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
+func ExampleStructLike() {
+	t := &testing.T{}
+
+	type PersonDTO struct {
+		Name     string `json:"name"`
+		Age      int    `json:"age"`
+		Password string `json:"-"`
+	}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := PersonDTO{
+		Name:     "Foobar",
+		Age:      42,
+		Password: "secret",
+	}
+
+	// Types differ, but fields are matched by name: Password is extra
+	person := Person{Name: "Foobar", Age: 42}
+	ok := CmpDeeply(t, got, StructLike(person),
+		"checks %v is like a Person")
+	fmt.Println(ok)
+
+	// Using a map and json tags, Password is ignored
+	entries := map[string]interface{}{
+		"name": "Foobar",
+		"age":  Between(40, 45),
+	}
+	ok = CmpDeeply(t, got, StructLike(entries, "json"),
+		"checks %v is like the map, using json tags")
+	fmt.Println(ok)
+
+	// Person fields are named Name & Age, not name & age
+	ok = CmpDeeply(t, got, StructLike(person, "json"),
+		"checks %v is like a Person, using json tags")
+	fmt.Println(ok)
+
+	// Output:
+	// false
+	// true
+	// false
+}
+
 func ExampleSubBagOf() {
 	t := &testing.T{}
 
@@ -1434,6 +2022,24 @@ func ExampleSubSetOf() {
 	// true
 }
 
+func ExampleSubsequence() {
+	t := &testing.T{}
+
+	got := []string{"start", "open", "read", "read", "close", "stop"}
+
+	ok := CmpDeeply(t, got, Subsequence("open", "read", "close"),
+		"checks %v contains open, read & close in this order", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Subsequence("close", "open"),
+		"checks %v contains close then open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleSuperBagOf() {
 	t := &testing.T{}
 
@@ -1452,6 +2058,24 @@ func ExampleSuperBagOf() {
 	// true
 }
 
+func ExampleSuperSliceOf() {
+	t := &testing.T{}
+
+	got := []int{42, 58, 26, 12, 7}
+
+	ok := CmpDeeply(t, got, SuperSliceOf([]int{42}, ArrayEntries{1: Gt(50)}),
+		"checks first items of slice %v", got)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, SuperSliceOf([]int{42}, ArrayEntries{-1: 7}),
+		"checks first & last items of slice %v", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
 func ExampleSuperMapOf_map() {
 	t := &testing.T{}
 
@@ -1507,6 +2131,32 @@ func ExampleSuperSetOf() {
 	// true
 }
 
+func ExampleTimes() {
+	t := &testing.T{}
+
+	got := []int{1, 1, 1, 1, 1, 2, 3}
+
+	// Matches as 1 is present 5 times
+	ok := CmpDeeply(t, got, Bag(Times(1, 5), 2, 3),
+		"checks 1 is present 5 times, in any order")
+	fmt.Println(ok)
+
+	// Matches as 1 is present between 2 and 5 times
+	ok = CmpDeeply(t, got, Bag(Times(1, Between(2, 5)), 2, 3),
+		"checks 1 is present 2 to 5 times, in any order")
+	fmt.Println(ok)
+
+	// Does not match as 1 is present 5 times, not 4
+	ok = CmpDeeply(t, got, Bag(Times(1, 4), 2, 3),
+		"checks 1 is present 4 times, in any order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleTruncTime() {
 	t := &testing.T{}
 
@@ -1544,6 +2194,72 @@ func ExampleTruncTime() {
 	// true
 }
 
+func ExampleUnique() {
+	t := &testing.T{}
+
+	got := []int{1, 3, 2, 7}
+
+	ok := CmpDeeply(t, got, Unique(), "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleUnique_key() {
+	t := &testing.T{}
+
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	got := []Person{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "bob"},
+	}
+
+	ok := CmpDeeply(t, got, Unique("ID"), "checks IDs are unique")
+	fmt.Println(ok)
+
+	lowerName := func(p Person) string { return strings.ToLower(p.Name) }
+
+	ok = CmpDeeply(t, got, Unique(lowerName),
+		"checks names are unique, case insensitively")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleWithin() {
+	t := &testing.T{}
+
+	expected := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := expected.Add(3 * time.Second)
+
+	ok := CmpDeeply(t, got, Within(expected, 5*time.Second),
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Locations do not matter
+	paris := time.FixedZone("CET", 3600)
+	ok = CmpDeeply(t, got.In(paris), Within(expected, 5*time.Second),
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	ok = CmpDeeply(t, got, Within(expected, time.Second),
+		"checks %v is within 1s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleZero() {
 	t := &testing.T{}
 
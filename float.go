@@ -0,0 +1,152 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+)
+
+// FloatMode describes how floats and complex numbers are compared,
+// see ContextConfig.FloatMode field. Its zero value compares them
+// exactly, as the == operator does.
+//
+// Two numbers are equal if they are exactly equal, or if their
+// difference is within one of the non-zero tolerances. Complex
+// numbers are compared using their modulus for AbsTol and RelTol,
+// and part by part for ULPs.
+type FloatMode struct {
+	// AbsTol is the absolute tolerance: numbers are equal if
+	// |got - expected| ≤ AbsTol.
+	AbsTol float64
+	// RelTol is the relative tolerance: numbers are equal if
+	// |got - expected| ≤ RelTol × max(|got|, |expected|).
+	RelTol float64
+	// ULPs is the maximum distance in units in the last place: numbers
+	// are equal if at most ULPs representable floats separate them.
+	ULPs uint
+	// NaNEqual, if true, considers NaN as equal to NaN. By default NaN
+	// is not equal to anything, including itself.
+	NaNEqual bool
+	// SignedZeros, if true, considers -0 as different from 0. By
+	// default -0 is equal to 0.
+	SignedZeros bool
+}
+
+// equal returns true if float "got" equals "expected" according to
+// m. "bitSize" is the size of the compared floats, 32 or 64.
+func (m *FloatMode) equal(got, expected float64, bitSize int) bool {
+	if math.IsNaN(got) || math.IsNaN(expected) {
+		return m.NaNEqual && math.IsNaN(got) && math.IsNaN(expected)
+	}
+
+	if got == expected {
+		return !m.SignedZeros || math.Signbit(got) == math.Signbit(expected)
+	}
+
+	if math.IsInf(got, 0) || math.IsInf(expected, 0) {
+		return false
+	}
+
+	diff := math.Abs(got - expected)
+	return m.withinTol(diff, math.Max(math.Abs(got), math.Abs(expected))) ||
+		(m.ULPs > 0 && ulpDistance(got, expected, bitSize) <= uint64(m.ULPs))
+}
+
+// equalComplex returns true if complex "got" equals "expected"
+// according to m. "bitSize" is the size of the compared complex
+// numbers, 64 or 128.
+func (m *FloatMode) equalComplex(got, expected complex128, bitSize int) bool {
+	if cmplx.IsNaN(got) || cmplx.IsNaN(expected) {
+		return m.NaNEqual && cmplx.IsNaN(got) && cmplx.IsNaN(expected)
+	}
+
+	if got == expected {
+		return !m.SignedZeros ||
+			(math.Signbit(real(got)) == math.Signbit(real(expected)) &&
+				math.Signbit(imag(got)) == math.Signbit(imag(expected)))
+	}
+
+	if cmplx.IsInf(got) || cmplx.IsInf(expected) {
+		return false
+	}
+
+	if m.withinTol(cmplx.Abs(got-expected),
+		math.Max(cmplx.Abs(got), cmplx.Abs(expected))) {
+		return true
+	}
+
+	if m.ULPs > 0 {
+		partSize := bitSize / 2
+		return ulpDistance(real(got), real(expected), partSize) <= uint64(m.ULPs) &&
+			ulpDistance(imag(got), imag(expected), partSize) <= uint64(m.ULPs)
+	}
+	return false
+}
+
+// withinTol returns true if "diff" is within m absolute or relative
+// tolerance, "magnitude" being the greatest magnitude of the compared
+// numbers.
+func (m *FloatMode) withinTol(diff, magnitude float64) bool {
+	return (m.AbsTol > 0 && diff <= m.AbsTol) ||
+		(m.RelTol > 0 && diff <= m.RelTol*magnitude)
+}
+
+// ulpDistance returns the number of representable floats of size
+// "bitSize" (32 or 64) between "a" and "b". -0 and 0 are at a
+// distance of 0.
+func ulpDistance(a, b float64, bitSize int) uint64 {
+	var ia, ib int64
+	if bitSize == 32 {
+		ia = int64(orderedBits32(float32(a)))
+		ib = int64(orderedBits32(float32(b)))
+	} else {
+		ia, ib = orderedBits64(a), orderedBits64(b)
+	}
+
+	if ia > ib {
+		return uint64(ia) - uint64(ib)
+	}
+	return uint64(ib) - uint64(ia)
+}
+
+// orderedBits64 returns the bits of "f" as an int64, ordered as
+// floats are: adjacent floats have adjacent values.
+func orderedBits64(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
+
+// orderedBits32 is the same as orderedBits64 but for float32.
+func orderedBits32(f float32) int32 {
+	bits := int32(math.Float32bits(f))
+	if bits < 0 {
+		return math.MinInt32 - bits
+	}
+	return bits
+}
+
+// floatString returns float or complex number "v" as a string,
+// respecting its size. It works even if "v" cannot be interfaced, as
+// an unexported struct field.
+func floatString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Float32:
+		return fmt.Sprint(float32(v.Float()))
+	case reflect.Float64:
+		return fmt.Sprint(v.Float())
+	case reflect.Complex64:
+		return fmt.Sprint(complex64(v.Complex()))
+	default: // reflect.Complex128
+		return fmt.Sprint(v.Complex())
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFloatMode(t *testing.T) {
+	type price struct {
+		Amount float64
+		Rates  []float32
+		Phase  complex128
+	}
+
+	a, b := 0.1, 0.2
+	got := price{
+		Amount: a + b,
+		Rates:  []float32{1.0000001, 2},
+		Phase:  complex(a+b, 1),
+	}
+	expected := price{
+		Amount: 0.3,
+		Rates:  []float32{1, 2},
+		Phase:  complex(0.3, 1),
+	}
+
+	check := func(mode FloatMode, got, expected interface{}) bool {
+		t.Helper()
+
+		ctx := NewContextWithConfig("DATA", ContextConfig{FloatMode: mode})
+		vgot, vexpected := reflect.ValueOf(got), reflect.ValueOf(expected)
+
+		err := deepValueEqual(ctx, vgot, vexpected)
+		if (deepValueEqual(ctx.boolean(), vgot, vexpected) == nil) != (err == nil) {
+			t.Errorf("boolean and non-boolean contexts disagree: %v", err)
+		}
+		return err == nil
+	}
+
+	if check(FloatMode{}, got, expected) {
+		t.Error("floats should be compared exactly by default")
+	}
+	if !check(FloatMode{ULPs: 1}, got, expected) {
+		t.Error("ULPs: floats should be equal")
+	}
+	if !check(FloatMode{RelTol: 1e-6}, got, expected) {
+		t.Error("RelTol: floats should be equal")
+	}
+	if check(FloatMode{RelTol: 1e-9}, got, expected) {
+		t.Error("RelTol: float32 should differ")
+	}
+	if !check(FloatMode{AbsTol: 1e-6}, got, expected) {
+		t.Error("AbsTol: floats should be equal")
+	}
+	if !check(FloatMode{RelTol: 1e-9}, []float64{1e-20}, []float64{1.0000000001e-20}) {
+		t.Error("RelTol: tiny floats should be equal")
+	}
+	if check(FloatMode{AbsTol: 1e-6}, []float64{1e-20}, []float64{1e-6 + 1e-20 + 1e-15}) {
+		t.Error("AbsTol: floats should differ")
+	}
+
+	// Also used by operators
+	if !check(FloatMode{ULPs: 1}, got, Struct(price{Amount: 0.3}, StructFields{
+		"Rates": []float32{1, 2},
+		"Phase": complex(0.3, 1),
+	})) {
+		t.Error("ULPs: floats should be equal")
+	}
+
+	//
+	// NaN
+	nan := math.NaN()
+	if check(FloatMode{}, nan, nan) {
+		t.Error("NaN should differ from NaN by default")
+	}
+	if !check(FloatMode{NaNEqual: true}, nan, nan) {
+		t.Error("NaN should equal NaN")
+	}
+	if check(FloatMode{NaNEqual: true}, nan, 1.0) {
+		t.Error("NaN should differ from 1")
+	}
+	if !check(FloatMode{NaNEqual: true}, []float32{float32(nan)}, []float32{float32(nan)}) {
+		t.Error("float32 NaN should equal NaN")
+	}
+	if !check(FloatMode{NaNEqual: true}, complex(nan, 1), complex(1, nan)) {
+		t.Error("complex NaN should equal complex NaN")
+	}
+	if check(FloatMode{}, nan, NRel(nan, 1)) {
+		t.Error("NRel: NaN should differ from NaN by default")
+	}
+	if !check(FloatMode{NaNEqual: true}, nan, NRel(nan, 1)) {
+		t.Error("NRel: NaN should equal NaN")
+	}
+	if !check(FloatMode{NaNEqual: true}, nan, NULP(nan, 1)) {
+		t.Error("NULP: NaN should equal NaN")
+	}
+
+	//
+	// -0
+	negZero := math.Copysign(0, -1)
+	if !check(FloatMode{}, negZero, 0.0) {
+		t.Error("-0 should equal 0 by default")
+	}
+	if check(FloatMode{SignedZeros: true}, negZero, 0.0) {
+		t.Error("-0 should differ from 0")
+	}
+	if !check(FloatMode{SignedZeros: true}, negZero, negZero) {
+		t.Error("-0 should equal -0")
+	}
+	if check(FloatMode{SignedZeros: true}, complex(1, negZero), complex(1, 0)) {
+		t.Error("complex -0 should differ from 0")
+	}
+	if check(FloatMode{SignedZeros: true}, negZero, NULP(0.0, 1)) {
+		t.Error("NULP: -0 should differ from 0")
+	}
+
+	//
+	// Infinities
+	inf := math.Inf(1)
+	if !check(FloatMode{RelTol: 1}, inf, inf) {
+		t.Error("+Inf should equal +Inf")
+	}
+	if check(FloatMode{RelTol: 1, ULPs: 1}, math.MaxFloat64, inf) {
+		t.Error("MaxFloat64 should differ from +Inf")
+	}
+	if check(FloatMode{RelTol: 1}, complex(inf, 0), complex(1, 0)) {
+		t.Error("complex +Inf should differ from 1")
+	}
+}
+
+func TestULPDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b     float64
+		bitSize  int
+		expected uint64
+	}{
+		{a: 1, b: 1, bitSize: 64, expected: 0},
+		{a: 1, b: math.Nextafter(1, 2), bitSize: 64, expected: 1},
+		{a: math.Nextafter(1, 0), b: math.Nextafter(1, 2), bitSize: 64, expected: 2},
+		{a: 0, b: math.Copysign(0, -1), bitSize: 64, expected: 0},
+		{a: -math.SmallestNonzeroFloat64, b: math.SmallestNonzeroFloat64,
+			bitSize: 64, expected: 2},
+		{a: -math.MaxFloat64, b: math.MaxFloat64,
+			bitSize: 64, expected: 2 * (math.MaxInt64 - 1<<52)},
+		{a: 1, b: float64(math.Nextafter32(1, 2)), bitSize: 32, expected: 1},
+		{a: -1, b: float64(math.Nextafter32(-1, 0)), bitSize: 32, expected: 1},
+	} {
+		if got := ulpDistance(test.a, test.b, test.bitSize); got != test.expected {
+			t.Errorf("ulpDistance(%v, %v, %d): got %d, expected %d",
+				test.a, test.b, test.bitSize, got, test.expected)
+		}
+		if got := ulpDistance(test.b, test.a, test.bitSize); got != test.expected {
+			t.Errorf("ulpDistance(%v, %v, %d): got %d, expected %d",
+				test.b, test.a, test.bitSize, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"sync"
+)
+
+// formatterSet maps a type to the function used to render values of
+// this type.
+type formatterSet map[reflect.Type]reflect.Value
+
+var globalFormatters = struct {
+	sync.RWMutex
+	set formatterSet
+}{
+	set: formatterSet{},
+}
+
+// checkFormatter checks that "fn" is a func(X) string function and
+// returns X type and "fn" as a reflect.Value.
+func checkFormatter(fn interface{}) (reflect.Type, reflect.Value) {
+	vfn := reflect.ValueOf(fn)
+	if vfn.Kind() == reflect.Func {
+		fnType := vfn.Type()
+		if fnType.NumIn() == 1 && !fnType.IsVariadic() &&
+			fnType.NumOut() == 1 && fnType.Out(0).Kind() == reflect.String {
+			return fnType.In(0), vfn
+		}
+	}
+	panic("usage: RegisterFormatter(func(X) string)")
+}
+
+// RegisterFormatter registers "fn" as the function to use each time a
+// value of type X has to be rendered in a failure report, X being the
+// type of the unique parameter of "fn". "fn" must be a func(X) string
+// function. If X is an interface type, "fn" is used for all types
+// implementing it, unless a formatter is registered for the exact
+// type of the value.
+//
+//   RegisterFormatter(func(id UUID) string {
+//     return hex.EncodeToString(id[:])
+//   })
+//
+// Registering a formatter for a type already having one replaces it.
+//
+// The formatters registered using this function are used by all
+// tests. See (*T).RegisterFormatter method to register a formatter
+// for a specific T instance.
+func RegisterFormatter(fn interface{}) {
+	typ, vfn := checkFormatter(fn)
+
+	globalFormatters.Lock()
+	globalFormatters.set[typ] = vfn
+	globalFormatters.Unlock()
+}
+
+// RegisterFormatter registers "fn" as the function to use each time a
+// value of type X has to be rendered in a failure report of this T
+// instance. Such formatters take precedence over those registered
+// using the global RegisterFormatter function. See it for details
+// about "fn".
+//
+// Note that the String method of TestDeep operators, used to render
+// expected values containing operators, only uses the global
+// formatters, as it cannot know the T instance.
+func (t *T) RegisterFormatter(fn interface{}) {
+	typ, vfn := checkFormatter(fn)
+
+	// Copy the set, as t.Config can be shared with other T instances
+	formatters := make(formatterSet, len(t.Config.formatters)+1)
+	for ftype, ffn := range t.Config.formatters {
+		formatters[ftype] = ffn
+	}
+	formatters[typ] = vfn
+
+	t.Config.formatters = formatters
+}
+
+// lookup returns the formatter to use for "typ", first in "f", then
+// in global formatters.
+func (f formatterSet) lookup(typ reflect.Type) (reflect.Value, bool) {
+	if fn, ok := f.lookupIn(typ); ok {
+		return fn, true
+	}
+
+	globalFormatters.RLock()
+	defer globalFormatters.RUnlock()
+
+	return globalFormatters.set.lookupIn(typ)
+}
+
+func (f formatterSet) lookupIn(typ reflect.Type) (reflect.Value, bool) {
+	if len(f) == 0 {
+		return reflect.Value{}, false
+	}
+
+	if fn, ok := f[typ]; ok {
+		return fn, true
+	}
+
+	for ftype, fn := range f {
+		if ftype.Kind() == reflect.Interface && typ.Implements(ftype) {
+			return fn, true
+		}
+	}
+	return reflect.Value{}, false
+}
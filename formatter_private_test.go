@@ -0,0 +1,182 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type formatterUUID [4]byte
+
+type formatterColor int
+
+const (
+	formatterRed formatterColor = iota + 1
+	formatterBlue
+)
+
+func (c formatterColor) String() string {
+	switch c {
+	case formatterRed:
+		return "Red"
+	case formatterBlue:
+		return "Blue"
+	}
+	return "?"
+}
+
+type formatterShape interface {
+	Area() int
+}
+
+type formatterSquare int
+
+func (s formatterSquare) Area() int { return int(s * s) }
+
+func TestFormatters(t *testing.T) {
+	// Enums
+	equalStr(t, toString(formatterRed), "Red (1)")
+	equalStr(t, toString([]formatterColor{formatterRed, formatterBlue}),
+		`([]testdeep.formatterColor) (len=2 cap=2) {
+ (testdeep.formatterColor) Red (1),
+ (testdeep.formatterColor) Blue (2)
+}`)
+
+	// Global formatter
+	RegisterFormatter(func(id formatterUUID) string {
+		return fmt.Sprintf("uuid:%x", id[:])
+	})
+
+	equalStr(t, toString(formatterUUID{1, 2, 3, 4}), "uuid:01020304")
+	equalStr(t, toString(reflect.ValueOf(formatterUUID{1, 2, 3, 4})),
+		"uuid:01020304")
+	equalStr(t, toString(struct {
+		ID    formatterUUID
+		Name  string
+		Color formatterColor
+	}{
+		ID:    formatterUUID{0xca, 0xfe, 0xba, 0xbe},
+		Name:  "Bob",
+		Color: formatterBlue,
+	}),
+		`(struct { ID testdeep.formatterUUID; Name string; Color testdeep.formatterColor }) {
+ ID: (testdeep.formatterUUID) uuid:cafebabe,
+ Name: (string) (len=3) "Bob",
+ Color: (testdeep.formatterColor) Blue (2)
+}`)
+
+	// Interface formatter
+	RegisterFormatter(func(s formatterShape) string {
+		return fmt.Sprintf("shape of area %d", s.Area())
+	})
+	equalStr(t, toString(formatterSquare(3)), "shape of area 9")
+
+	// T formatters take precedence over global ones
+	tt := NewT(t)
+	tt.RegisterFormatter(func(id formatterUUID) string {
+		return fmt.Sprintf("ID(%d)", id[3])
+	})
+
+	err := Error{
+		Context:  NewContextWithConfig("DATA", tt.Config),
+		Message:  "Error message",
+		Got:      formatterUUID{1, 2, 3, 4},
+		Expected: []formatterUUID{{5, 6, 7, 8}},
+	}
+	equalStr(t, err.Error(),
+		`DATA: Error message
+	     got: ID(4)
+	expected: ([]testdeep.formatterUUID) (len=1 cap=1) {
+	           (testdeep.formatterUUID) ID(8)
+	          }`)
+
+	// Summaries also use T formatters
+	err = Error{
+		Context: NewContextWithConfig("DATA", tt.Config),
+		Message: "Error message",
+		Summary: tdSetResult{
+			Kind: itemsSetResult,
+			Missing: []reflect.Value{
+				reflect.ValueOf(formatterUUID{1, 2, 3, 4}),
+			},
+		},
+	}
+	equalStr(t, err.Error(),
+		`DATA: Error message
+	Missing items: (ID(4))`)
+
+	// Global formatters are still used by other T instances
+	err.Context = NewContext("DATA")
+	equalStr(t, err.Error(),
+		`DATA: Error message
+	Missing items: (uuid:01020304)`)
+
+	// Bad usage
+	for _, fn := range []interface{}{
+		nil,
+		42,
+		func() string { return "" },
+		func(int) int { return 0 },
+		func(int, int) string { return "" },
+		func(...int) string { return "" },
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil || !strings.Contains(fmt.Sprint(r), "usage: RegisterFormatter(") {
+					t.Errorf("RegisterFormatter(%T) did not panic as expected: %v", fn, r)
+				}
+			}()
+			RegisterFormatter(fn)
+		}()
+	}
+}
+
+type formatterNode struct {
+	Color formatterColor
+	Next  *formatterNode
+}
+
+func TestFormattersCircular(t *testing.T) {
+	node := &formatterNode{Color: formatterRed}
+	node.Next = node
+
+	reAddr := regexp.MustCompile(`0x[0-9a-f]+`)
+
+	equalStr(t, reAddr.ReplaceAllString(toString(node), "0xADDR"),
+		`(*testdeep.formatterNode)(0xADDR)({
+ Color: (testdeep.formatterColor) Red (1),
+ Next: (*testdeep.formatterNode)(0xADDR)(<already shown>)
+})`)
+
+	// Same pointer but not circular
+	other := &formatterNode{Color: formatterBlue}
+	equalStr(t,
+		reAddr.ReplaceAllString(toString([]*formatterNode{other, other}), "0xADDR"),
+		`([]*testdeep.formatterNode) (len=2 cap=2) {
+ (*testdeep.formatterNode)(0xADDR)({
+  Color: (testdeep.formatterColor) Blue (2),
+  Next: (*testdeep.formatterNode)(<nil>)
+ }),
+ (*testdeep.formatterNode)(0xADDR)({
+  Color: (testdeep.formatterColor) Blue (2),
+  Next: (*testdeep.formatterNode)(<nil>)
+ })
+}`)
+
+	err := EqDeeplyError(node, Nil())
+	if err == nil {
+		t.Fatal("An error should have occurred")
+	}
+	if !strings.Contains(err.Error(), "(<already shown>)") {
+		t.Errorf("circular value not rendered as expected:\n%s", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldMatchers caches the matchers of ContextConfig.IgnoreFields
+// patterns, so each pattern is compiled only once.
+var fieldMatchers = struct {
+	sync.RWMutex
+	cache map[string]func(string) bool
+}{
+	cache: map[string]func(string) bool{},
+}
+
+// getFieldMatcher returns the matcher of "pattern", a field name, a
+// regexp prefixed by "=~" or a glob pattern.
+func getFieldMatcher(pattern string) func(string) bool {
+	fieldMatchers.RLock()
+	matcher := fieldMatchers.cache[pattern]
+	fieldMatchers.RUnlock()
+
+	if matcher != nil {
+		return matcher
+	}
+
+	if isFieldPattern(pattern) {
+		matcher = newFieldMatcher(pattern)
+	} else {
+		matcher = func(name string) bool { return name == pattern }
+	}
+
+	fieldMatchers.Lock()
+	fieldMatchers.cache[pattern] = matcher
+	fieldMatchers.Unlock()
+
+	return matcher
+}
+
+// matchFieldTag returns true if "field" has the tag described by
+// "spec", either a tag key alone as "testdeep" or a key/value pair as
+// `testdeep:"-"`.
+func matchFieldTag(field *structField, spec string) bool {
+	pos := strings.IndexByte(spec, ':')
+	if pos < 0 {
+		_, ok := field.tag.Lookup(spec)
+		return ok
+	}
+
+	value, ok := field.tag.Lookup(spec[:pos])
+	return ok && value == strings.Trim(spec[pos+1:], `"`)
+}
+
+// ignoreField returns true if "field" has not to be compared
+// according to c config, and the reason why.
+func (c Context) ignoreField(field *structField) (string, bool) {
+	config := c.getConfig()
+
+	if !field.exported {
+		if config.IgnoreUnexported {
+			return "unexported", true
+		}
+		for _, typ := range config.IgnoreUnexportedOf {
+			if field.owner == typ {
+				return "unexported in " + typ.String(), true
+			}
+		}
+	}
+
+	for _, pattern := range config.IgnoreFields {
+		if getFieldMatcher(pattern)(field.name) {
+			return "name matches `" + pattern + "'", true
+		}
+	}
+
+	for _, typ := range config.IgnoreFieldTypes {
+		if field.typ == typ {
+			return "type is " + typ.String(), true
+		}
+	}
+
+	for _, spec := range config.IgnoreFieldTags {
+		if matchFieldTag(field, spec) {
+			return "tag matches `" + spec + "'", true
+		}
+	}
+
+	return "", false
+}
+
+// recordIgnored records, if needed, that the field c is pointing to
+// has been ignored for "reason" though "got" and "expected" differ.
+func (c Context) recordIgnored(reason string, got, expected reflect.Value) {
+	if c.booleanError || c.ignoredDiffs == nil {
+		return
+	}
+
+	if deepValueEqual(c.boolean(), got, expected) != nil {
+		*c.ignoredDiffs = append(*c.ignoredDiffs,
+			c.Path()+": ignored field, as "+reason)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIgnoreFields(t *testing.T) {
+	type inner struct {
+		CreatedAt time.Time
+		Value     int
+	}
+	type record struct {
+		ID            int
+		CreatedAt     time.Time
+		XXX_sizecache int32
+		mu            sync.Mutex
+		Cache         string `testdeep:"-"`
+		Label         string `json:"label" testdeep:"skip"`
+		Inner         inner
+		Ptr           *inner
+	}
+
+	now := time.Now()
+	got := &record{
+		ID:            12,
+		CreatedAt:     now,
+		XXX_sizecache: 34,
+		Cache:         "foo",
+		Label:         "bar",
+		Inner:         inner{CreatedAt: now, Value: 1},
+		Ptr:           &inner{CreatedAt: now, Value: 2},
+	}
+	expected := &record{
+		ID:    12,
+		Inner: inner{Value: 1},
+		Ptr:   &inner{Value: 2},
+	}
+
+	check := func(config ContextConfig, got, expected interface{}) *Error {
+		t.Helper()
+		return deepValueEqual(NewContextWithConfig("DATA", config),
+			reflect.ValueOf(got), reflect.ValueOf(expected))
+	}
+
+	config := ContextConfig{
+		IgnoreFields:     []string{"CreatedAt", "XXX_*"},
+		IgnoreFieldTypes: []reflect.Type{reflect.TypeOf(sync.Mutex{})},
+		IgnoreFieldTags:  []string{`testdeep:"-"`, "json"},
+	}
+	if err := check(config, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Same with operators
+	if err := check(config, got, Struct(&record{ID: 12}, StructFields{
+		"Inner": inner{Value: 1},
+		"Ptr":   Ptr(inner{Value: 2}),
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// but fields explicitly listed in StructFields are always checked
+	if err := check(config, got, Struct(&record{ID: 12}, StructFields{
+		"CreatedAt":     Not(Zero()),
+		"XXX_sizecache": int32(34),
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	for _, fields := range []StructFields{
+		{"CreatedAt": Zero()},
+		{"=~^Created": Zero()},
+		{"Cache": "bar"},
+		{"Inner.CreatedAt": Zero()},
+	} {
+		err := check(config, got, Struct(&record{}, fields))
+		if err == nil {
+			t.Errorf("an error was expected with %v", fields)
+		} else if !strings.Contains(err.Context.Path(), ".Cache") &&
+			!strings.Contains(err.Context.Path(), "CreatedAt") {
+			t.Errorf("unexpected error path: %s", err.Context.Path())
+		}
+	}
+	if err := check(config, got, SStruct(&record{ID: 12}, StructFields{
+		"Inner": Struct(inner{Value: 1}, nil),
+		"Ptr":   Not(nil),
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := check(config, got, StructLike(map[string]interface{}{
+		"ID":    12,
+		"Inner": map[string]interface{}{"Value": 1},
+		"Ptr":   Ignore(),
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Regexp pattern & tag key with another value
+	config = ContextConfig{
+		IgnoreFields:    []string{"=~^(CreatedAt|XXX_)", "mu"},
+		IgnoreFieldTags: []string{`testdeep:"skip"`},
+	}
+	err := check(config, got, expected)
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	equalStr(t, err.Context.Path(), "(*DATA).Cache")
+
+	// Not ignored at all
+	err = check(ContextConfig{}, got, expected)
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	if path := err.Context.Path(); !strings.HasPrefix(path, "(*DATA).CreatedAt") {
+		t.Errorf("CreatedAt should not be ignored, error at %s", path)
+	}
+
+	//
+	// Report ignored differences
+	config = ContextConfig{
+		IgnoreFields:       []string{"CreatedAt", "XXX_*"},
+		IgnoreFieldTypes:   []reflect.Type{reflect.TypeOf(sync.Mutex{})},
+		IgnoreFieldTags:    []string{"testdeep"},
+		ReportIgnoredDiffs: true,
+	}
+	ctx := NewContextWithConfig("DATA", config)
+	ctx.ignoredDiffs = new([]string)
+	expected.Ptr.Value = 3
+	err = deepValueEqual(ctx, reflect.ValueOf(got), reflect.ValueOf(expected))
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	equalStr(t, err.Context.Path(), "(*(*DATA).Ptr).Value")
+
+	if len(*ctx.ignoredDiffs) != 6 {
+		t.Fatalf("6 ignored differences expected, got %d: %q",
+			len(*ctx.ignoredDiffs), *ctx.ignoredDiffs)
+	}
+	for i, diff := range []string{
+		"(*DATA).CreatedAt: ignored field, as name matches `CreatedAt'",
+		"(*DATA).XXX_sizecache: ignored field, as name matches `XXX_*'",
+		"(*DATA).Cache: ignored field, as tag matches `testdeep'",
+		"(*DATA).Label: ignored field, as tag matches `testdeep'",
+		"(*DATA).Inner.CreatedAt: ignored field, as name matches `CreatedAt'",
+		"(*(*DATA).Ptr).CreatedAt: ignored field, as name matches `CreatedAt'",
+	} {
+		equalStr(t, (*ctx.ignoredDiffs)[i], diff)
+	}
+
+	// Nothing recorded in boolean contexts
+	ctx = NewContextWithConfig("DATA", config).boolean()
+	ctx.ignoredDiffs = new([]string)
+	if deepValueEqual(ctx, reflect.ValueOf(got), reflect.ValueOf(expected)) == nil {
+		t.Fatal("an error was expected")
+	}
+	if len(*ctx.ignoredDiffs) != 0 {
+		t.Errorf("no ignored differences expected, got %q", *ctx.ignoredDiffs)
+	}
+}
+
+func TestIgnoreUnexported(t *testing.T) {
+	type base struct {
+		Name string
+		id   int
+	}
+	type record struct {
+		base
+		Value int
+		cache map[string]int
+	}
+
+	got := record{
+		base:  base{Name: "Bob", id: 1},
+		Value: 12,
+		cache: map[string]int{"a": 1},
+	}
+	expected := record{
+		base:  base{Name: "Bob", id: 2},
+		Value: 12,
+	}
+
+	check := func(config ContextConfig, expected interface{}) *Error {
+		t.Helper()
+		return deepValueEqual(NewContextWithConfig("DATA", config),
+			reflect.ValueOf(got), reflect.ValueOf(expected))
+	}
+
+	// Unexported fields are compared by default
+	err := check(ContextConfig{}, expected)
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	equalStr(t, err.Context.Path(), "DATA.base.id")
+
+	if err = check(ContextConfig{IgnoreUnexported: true}, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Only unexported fields of base are ignored
+	config := ContextConfig{
+		IgnoreUnexportedOf: []reflect.Type{reflect.TypeOf(base{})},
+	}
+	err = check(config, expected)
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	equalStr(t, err.Context.Path(), "DATA.cache")
+
+	expected.cache = map[string]int{"a": 1}
+	if err = check(config, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Struct operators honour the same options, promoted fields included
+	if err = check(config, Struct(record{}, StructFields{
+		"Name":  "Bob",
+		"Value": 12,
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err = check(config, SStruct(record{}, StructFields{
+		"Name":  "Bob",
+		"Value": 12,
+		"cache": map[string]int{"a": 1},
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// except for fields explicitly listed in StructFields
+	err = check(config, Struct(record{}, StructFields{"id": 2}))
+	if err == nil {
+		t.Fatal("an error was expected")
+	}
+	equalStr(t, err.Context.Path(), "DATA.id")
+	if err = check(ContextConfig{IgnoreUnexported: true},
+		SStruct(record{Value: 12}, StructFields{
+			"Name": "Bob",
+		})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err = check(ContextConfig{IgnoreUnexported: true},
+		StructLike(map[string]interface{}{
+			"Name":  "Bob",
+			"Value": 12,
+		})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Reason recorded when reporting ignored differences
+	ctx := NewContextWithConfig("DATA", config)
+	ctx.ignoredDiffs = new([]string)
+	expected.Value = 13
+	if deepValueEqual(ctx, reflect.ValueOf(got), reflect.ValueOf(expected)) == nil {
+		t.Fatal("an error was expected")
+	}
+	if len(*ctx.ignoredDiffs) != 1 {
+		t.Fatalf("1 ignored difference expected, got %q", *ctx.ignoredDiffs)
+	}
+	equalStr(t, (*ctx.ignoredDiffs)[0],
+		"DATA.base.id: ignored field, as unexported in testdeep.base")
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathSegmentKind is the kind of a PathSegment.
+type PathSegmentKind uint8
+
+const (
+	// PathField is a struct field access, as in ".Name".
+	PathField PathSegmentKind = iota
+	// PathIndex is an array or slice index, as in "[12]".
+	PathIndex
+	// PathMapKey is a map key, as in "[key]".
+	PathMapKey
+	// PathPtr is one or several pointer dereferences, as in "*".
+	PathPtr
+	// PathFunctionCall is a function call, as in "len(…)".
+	PathFunctionCall
+	// PathCaptures is the set of captures of a regexp, as in
+	// "(… =~ regexp)".
+	PathCaptures
+	// PathRaw is a raw string appended as is to the path.
+	PathRaw
+)
+
+// Implements fmt.Stringer.
+func (k PathSegmentKind) String() string {
+	switch k {
+	case PathField:
+		return "field"
+	case PathIndex:
+		return "index"
+	case PathMapKey:
+		return "map key"
+	case PathPtr:
+		return "pointer"
+	case PathFunctionCall:
+		return "function call"
+	case PathCaptures:
+		return "captures"
+	case PathRaw:
+		return "raw"
+	default:
+		return "?"
+	}
+}
+
+// PathSegment is a segment of a Path.
+type PathSegment struct {
+	Kind PathSegmentKind
+	// Name is the field name for PathField, the function name for
+	// PathFunctionCall, the regexp for PathCaptures and the raw string
+	// for PathRaw.
+	Name string
+	// Index is the index for PathIndex and the number of dereferences
+	// for PathPtr.
+	Index int
+
+	key reflect.Value // for PathMapKey
+}
+
+// Key returns the map key for PathMapKey segments, nil otherwise.
+func (s PathSegment) Key() interface{} {
+	if s.Kind != PathMapKey {
+		return nil
+	}
+	key, _ := getInterface(s.key, true)
+	return key
+}
+
+// Path is the path leading to a value inside the data compared by
+// CmpDeeply & co.
+type Path struct {
+	// Root is the name of the root of the path, typically "DATA".
+	Root string
+	// Segments are the segments of the path, from the root.
+	Segments []PathSegment
+}
+
+// pathNode is an element of the linked list of path segments kept by
+// Context. Each node only refers to its parent, so it can be shared
+// by several Contexts.
+type pathNode struct {
+	parent  *pathNode
+	segment PathSegment
+	len     int
+}
+
+func (n *pathNode) add(segment PathSegment) *pathNode {
+	newNode := &pathNode{
+		parent:  n,
+		segment: segment,
+		len:     1,
+	}
+	if n != nil {
+		newNode.len += n.len
+	}
+	return newNode
+}
+
+// path returns the Path corresponding to n, the last segment of a
+// path whose root is "root".
+func (n *pathNode) path(root string) Path {
+	p := Path{Root: root}
+	if n != nil {
+		p.Segments = make([]PathSegment, n.len)
+		for node := n; node != nil; node = node.parent {
+			p.Segments[node.len-1] = node.segment
+		}
+	}
+	return p
+}
+
+// String returns the path the same way Context.Path() does, as in
+// "*DATA.Field[12]".
+func (p Path) String() string {
+	str := p.Root
+
+	for _, segment := range p.Segments {
+		switch segment.Kind {
+		case PathPtr:
+			str = strings.Repeat("*", segment.Index) + str
+
+		case PathFunctionCall:
+			str = segment.Name + "(" + str + ")"
+
+		case PathCaptures:
+			str = "(" + str + " =~ " + segment.Name + ")"
+
+		default:
+			if strings.HasPrefix(str, "*") {
+				str = "(" + str + ")"
+			}
+
+			switch segment.Kind {
+			case PathField:
+				str += "." + segment.Name
+			case PathIndex:
+				str += "[" + strconv.Itoa(segment.Index) + "]"
+			case PathMapKey:
+				str += "[" + toString(segment.key) + "]"
+			default: // PathRaw
+				str += segment.Name
+			}
+		}
+	}
+	return str
+}
+
+// JSONPointer returns the path following the JSON Pointer syntax (see
+// RFC 6901), as in "/Field/12". Pointer dereferences are omitted,
+// function calls and regexp captures are rendered as additional
+// reference tokens.
+func (p Path) JSONPointer() string {
+	buf := &bytes.Buffer{}
+
+	for _, segment := range p.Segments {
+		var token string
+
+		switch segment.Kind {
+		case PathPtr:
+			continue
+		case PathIndex:
+			token = strconv.Itoa(segment.Index)
+		case PathMapKey:
+			token = fmt.Sprint(segment.Key())
+		case PathFunctionCall:
+			token = segment.Name + "()"
+		case PathCaptures:
+			token = "captures(" + segment.Name + ")"
+		default: // PathField & PathRaw
+			token = segment.Name
+		}
+
+		buf.WriteByte('/')
+		buf.WriteString(jsonPointerEscaper.Replace(token))
+	}
+	return buf.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPath returns the path following a JSONPath like syntax, as in
+// "$.Field[12]['key']". Pointer dereferences are omitted, function
+// calls are rendered as in "$.Field.len()" and regexp captures as in
+// "$.Field.captures('regexp')".
+func (p Path) JSONPath() string {
+	buf := bytes.NewBufferString("$")
+
+	for _, segment := range p.Segments {
+		switch segment.Kind {
+		case PathPtr:
+		case PathField:
+			buf.WriteByte('.')
+			buf.WriteString(segment.Name)
+		case PathIndex:
+			fmt.Fprintf(buf, "[%d]", segment.Index) // nolint: errcheck
+		case PathMapKey:
+			switch key := segment.Key().(type) {
+			case int, int8, int16, int32, int64,
+				uint, uint8, uint16, uint32, uint64:
+				fmt.Fprintf(buf, "[%d]", key) // nolint: errcheck
+			default:
+				buf.WriteString("['")
+				buf.WriteString(jsonPathEscaper.Replace(fmt.Sprint(key)))
+				buf.WriteString("']")
+			}
+		case PathFunctionCall:
+			buf.WriteByte('.')
+			buf.WriteString(segment.Name)
+			buf.WriteString("()")
+		case PathCaptures:
+			buf.WriteString(".captures('")
+			buf.WriteString(jsonPathEscaper.Replace(segment.Name))
+			buf.WriteString("')")
+		default: // PathRaw
+			buf.WriteString(segment.Name)
+		}
+	}
+	return buf.String()
+}
+
+var jsonPathEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
@@ -10,12 +10,25 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
 )
 
+// formattersStringer is implemented by summaries able to render the
+// values they contain using specific formatters.
+type formattersStringer interface {
+	stringWith(formatters formatterSet) string
+}
+
 func toString(val interface{}) string {
+	return toStringWith(nil, val)
+}
+
+// toStringWith renders "val" using "formatters" then the globally
+// registered formatters.
+func toStringWith(formatters formatterSet, val interface{}) string {
 	if val == nil {
 		return "nil"
 	}
@@ -24,13 +37,33 @@ func toString(val interface{}) string {
 	case reflect.Value:
 		newVal, ok := getInterface(tval, true)
 		if ok {
-			return toString(newVal)
+			return toStringWith(formatters, newVal)
 		}
+		return strings.TrimRight(spew.Sdump(val), "\n")
+
+	case formattersStringer:
+		return tval.stringWith(formatters)
 
-	case fmt.Stringer:
+	case testDeepStringer:
 		return tval.String()
 	}
 
+	d := dumper{formatters: formatters}
+	vval := reflect.ValueOf(val)
+
+	if str, ok := d.special(vval); ok {
+		return str
+	}
+
+	if stringer, ok := val.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	if d.needsCustom(vval.Type(), nil) {
+		d.visited = map[uintptr]bool{}
+		return d.dump(vval)
+	}
+
 	return strings.TrimRight(spew.Sdump(val), "\n")
 }
 
@@ -38,22 +71,52 @@ func indentString(str string, indent string) string {
 	return strings.Replace(str, "\n", "\n"+indent, -1)
 }
 
+// columnStringer is implemented by TestDeep operators aligning their
+// continuation lines on the column where their rendering begins, see
+// sliceToBufferWith.
+type columnStringer interface {
+	stringToBuffer(buf *bytes.Buffer) *bytes.Buffer
+}
+
+// valueToBuffer writes "val" to "buf", its continuation lines being
+// indented by "indent". A columnStringer is directly rendered in
+// "buf" instead, so its continuation lines are aligned on the real
+// column where it begins.
+func valueToBuffer(buf *bytes.Buffer, val interface{}, indent string) *bytes.Buffer {
+	iface := val
+	if vval, ok := val.(reflect.Value); ok && vval.IsValid() && vval.CanInterface() {
+		iface = vval.Interface()
+	}
+	if cs, ok := iface.(columnStringer); ok {
+		return cs.stringToBuffer(buf)
+	}
+	buf.WriteString(indentString(toString(val), indent))
+	return buf
+}
+
 func sliceToBuffer(buf *bytes.Buffer, items []reflect.Value) *bytes.Buffer {
+	return sliceToBufferWith(nil, buf, items)
+}
+
+func sliceToBufferWith(formatters formatterSet,
+	buf *bytes.Buffer, items []reflect.Value) *bytes.Buffer {
 	buf.WriteByte('(')
+
+	begLine := bytes.LastIndexByte(buf.Bytes(), '\n') + 1
+	prefix := strings.Repeat(" ", buf.Len()-begLine)
+
 	if len(items) < 2 {
 		if len(items) > 0 {
-			buf.WriteString(toString(items[0]))
+			buf.WriteString(
+				indentString(toStringWith(formatters, items[0]), prefix))
 		}
 	} else {
-		begLine := bytes.LastIndexByte(buf.Bytes(), '\n') + 1
-
-		prefix := strings.Repeat(" ", buf.Len()-begLine)
-
 		for idx, item := range items {
 			if idx != 0 {
 				buf.WriteString(prefix)
 			}
-			buf.WriteString(toString(item))
+			buf.WriteString(
+				indentString(toStringWith(formatters, item), prefix))
 			buf.WriteString(",\n")
 		}
 		buf.Truncate(buf.Len() - 2)
@@ -62,3 +125,204 @@ func sliceToBuffer(buf *bytes.Buffer, items []reflect.Value) *bytes.Buffer {
 
 	return buf
 }
+
+// isEnumType returns true if "typ" is a named integer type
+// implementing fmt.Stringer interface, as enums often are.
+func isEnumType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return typ.PkgPath() != "" &&
+			typ != durationType &&
+			typ.Implements(stringerInterface) &&
+			!typ.Implements(testDeepStringerInterface)
+	}
+	return false
+}
+
+// dumper renders values the same way spew does, but using registered
+// formatters and displaying enums with their underlying number.
+type dumper struct {
+	formatters formatterSet
+	// pointers currently rendered, so a circular value is not
+	// rendered endlessly
+	visited map[uintptr]bool
+}
+
+// special renders "val" if it has to be rendered by a formatter or
+// if it is an enum. Otherwise it returns false.
+func (d dumper) special(val reflect.Value) (string, bool) {
+	if fn, ok := d.formatters.lookup(val.Type()); ok {
+		if !val.CanInterface() {
+			iface, ok := getInterface(val, true)
+			if !ok {
+				return "", false
+			}
+			val = reflect.ValueOf(iface)
+		}
+		return fn.Call([]reflect.Value{val})[0].String(), true
+	}
+
+	if isEnumType(val.Type()) {
+		iface, ok := getInterface(val, true)
+		if !ok {
+			return "", false
+		}
+
+		var num interface{}
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			num = val.Int()
+		default:
+			num = val.Uint()
+		}
+		return fmt.Sprintf("%s (%d)", iface.(fmt.Stringer).String(), num), true
+	}
+	return "", false
+}
+
+// needsCustom returns true if "typ" contains a type that has to be
+// rendered by a formatter or an enum type.
+func (d dumper) needsCustom(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if _, ok := d.formatters.lookup(typ); ok || isEnumType(typ) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Array, reflect.Map, reflect.Ptr, reflect.Slice, reflect.Struct:
+	default:
+		return false
+	}
+
+	if seen[typ] {
+		return false
+	}
+	if seen == nil {
+		seen = map[reflect.Type]bool{}
+	}
+	seen[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Map:
+		return d.needsCustom(typ.Key(), seen) || d.needsCustom(typ.Elem(), seen)
+
+	case reflect.Struct:
+		for i, n := 0, typ.NumField(); i < n; i++ {
+			if d.needsCustom(typ.Field(i).Type, seen) {
+				return true
+			}
+		}
+		return false
+
+	default: // Array, Ptr & Slice
+		return d.needsCustom(typ.Elem(), seen)
+	}
+}
+
+// dump renders "val" prefixed by its type.
+func (d dumper) dump(val reflect.Value) string {
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return "(" + val.Type().String() + ") <nil>"
+		}
+		val = val.Elem()
+	}
+
+	if !d.needsCustom(val.Type(), nil) {
+		iface, ok := getInterface(val, true)
+		if !ok {
+			return "(" + val.Type().String() + ") <unexported>"
+		}
+		return strings.TrimRight(spew.Sdump(iface), "\n")
+	}
+
+	if val.Kind() == reflect.Ptr {
+		return "(" + val.Type().String() + ")" + d.body(val)
+	}
+	return "(" + val.Type().String() + ") " + d.body(val)
+}
+
+// body renders "val" without its type, "val" type needing a custom
+// rendering (see needsCustom).
+func (d dumper) body(val reflect.Value) string {
+	if str, ok := d.special(val); ok {
+		return str
+	}
+
+	if val.Type().Implements(stringerInterface) {
+		if iface, ok := getInterface(val, true); ok {
+			return iface.(fmt.Stringer).String()
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return "(<nil>)"
+		}
+		ptr := val.Pointer()
+		if d.visited[ptr] {
+			return fmt.Sprintf("(0x%x)(<already shown>)", ptr)
+		}
+		d.visited[ptr] = true
+		defer delete(d.visited, ptr)
+		return fmt.Sprintf("(0x%x)(", ptr) + d.body(val.Elem()) + ")"
+
+	case reflect.Struct:
+		typ := val.Type()
+		entries := make([]string, val.NumField())
+		for i := range entries {
+			entries[i] = typ.Field(i).Name + ": " + d.dump(val.Field(i))
+		}
+		return blockString("", entries)
+
+	case reflect.Map:
+		if val.IsNil() {
+			return "<nil>"
+		}
+		entries := make([]string, 0, val.Len())
+		for _, key := range val.MapKeys() {
+			entries = append(entries, d.dump(key)+": "+d.dump(val.MapIndex(key)))
+		}
+		sort.Strings(entries)
+		return blockString(lenCapString(val.Len(), -1), entries)
+
+	default: // Array & Slice
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return "<nil>"
+		}
+		entries := make([]string, val.Len())
+		for i := range entries {
+			entries[i] = d.dump(val.Index(i))
+		}
+		return blockString(lenCapString(val.Len(), val.Cap()), entries)
+	}
+}
+
+func lenCapString(l, c int) string {
+	switch {
+	case l == 0 && c <= 0:
+		return ""
+	case c < 0:
+		return fmt.Sprintf("(len=%d) ", l)
+	default:
+		return fmt.Sprintf("(len=%d cap=%d) ", l, c)
+	}
+}
+
+// blockString renders "entries" inside a block, one entry per line,
+// as spew does.
+func blockString(prefix string, entries []string) string {
+	buf := bytes.NewBufferString(prefix)
+	buf.WriteString("{\n")
+	for idx, entry := range entries {
+		buf.WriteByte(' ')
+		buf.WriteString(indentString(entry, " "))
+		if idx < len(entries)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
@@ -12,6 +12,16 @@ import (
 	"time"
 )
 
+// After is a shortcut for:
+//
+//   t.CmpDeeply(got, After(expectedTime), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) After(got interface{}, expectedTime interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, After(expectedTime), args...)
+}
+
 // All is a shortcut for:
 //
 //   t.CmpDeeply(got, All(expectedValues...), args...)
@@ -36,6 +46,9 @@ func (t *T) Any(got interface{}, expectedValues []interface{}, args ...interface
 //
 //   t.CmpDeeply(got, Array(model, expectedEntries), args...)
 //
+// Array() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with Array() to pass it.
+//
 // Returns true if the test is OK, false if it fails.
 func (t *T) Array(got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
 	t.Helper()
@@ -52,6 +65,26 @@ func (t *T) ArrayEach(got interface{}, expectedValue interface{}, args ...interf
 	return t.CmpDeeply(got, ArrayEach(expectedValue), args...)
 }
 
+// AtLeast is a shortcut for:
+//
+//   t.CmpDeeply(got, AtLeast(expectedValue, min), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) AtLeast(got interface{}, expectedValue interface{}, min int, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, AtLeast(expectedValue, min), args...)
+}
+
+// AtMost is a shortcut for:
+//
+//   t.CmpDeeply(got, AtMost(expectedValue, max), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) AtMost(got interface{}, expectedValue interface{}, max int, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, AtMost(expectedValue, max), args...)
+}
+
 // Bag is a shortcut for:
 //
 //   t.CmpDeeply(got, Bag(expectedItems...), args...)
@@ -62,6 +95,26 @@ func (t *T) Bag(got interface{}, expectedItems []interface{}, args ...interface{
 	return t.CmpDeeply(got, Bag(expectedItems...), args...)
 }
 
+// BagBy is a shortcut for:
+//
+//   t.CmpDeeply(got, BagBy(by, expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) BagBy(got interface{}, by interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, BagBy(by, expectedItems...), args...)
+}
+
+// Before is a shortcut for:
+//
+//   t.CmpDeeply(got, Before(expectedTime), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Before(got interface{}, expectedTime interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Before(expectedTime), args...)
+}
+
 // Between is a shortcut for:
 //
 //   t.CmpDeeply(got, Between(from, to, bounds), args...)
@@ -106,6 +159,26 @@ func (t *T) Contains(got interface{}, expected string, args ...interface{}) bool
 	return t.CmpDeeply(got, Contains(expected), args...)
 }
 
+// Count is a shortcut for:
+//
+//   t.CmpDeeply(got, Count(expectedValue, count), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Count(got interface{}, expectedValue interface{}, count interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Count(expectedValue, count), args...)
+}
+
+// Finite is a shortcut for:
+//
+//   t.CmpDeeply(got, Finite(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Finite(got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Finite(), args...)
+}
+
 // Gt is a shortcut for:
 //
 //   t.CmpDeeply(got, Gt(val), args...)
@@ -146,6 +219,16 @@ func (t *T) HasSuffix(got interface{}, expected string, args ...interface{}) boo
 	return t.CmpDeeply(got, HasSuffix(expected), args...)
 }
 
+// Inf is a shortcut for:
+//
+//   t.CmpDeeply(got, Inf(sign), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Inf(got interface{}, sign int, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Inf(sign), args...)
+}
+
 // Isa is a shortcut for:
 //
 //   t.CmpDeeply(got, Isa(model), args...)
@@ -206,6 +289,26 @@ func (t *T) MapEach(got interface{}, expectedValue interface{}, args ...interfac
 	return t.CmpDeeply(got, MapEach(expectedValue), args...)
 }
 
+// MapEachEntry is a shortcut for:
+//
+//   t.CmpDeeply(got, MapEachEntry(fn), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) MapEachEntry(got interface{}, fn interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, MapEachEntry(fn), args...)
+}
+
+// MapEachKey is a shortcut for:
+//
+//   t.CmpDeeply(got, MapEachKey(expectedKey), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) MapEachKey(got interface{}, expectedKey interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, MapEachKey(expectedKey), args...)
+}
+
 // N is a shortcut for:
 //
 //   t.CmpDeeply(got, N(num, tolerance), args...)
@@ -220,6 +323,36 @@ func (t *T) N(got interface{}, num interface{}, tolerance interface{}, args ...i
 	return t.CmpDeeply(got, N(num, tolerance), args...)
 }
 
+// NRel is a shortcut for:
+//
+//   t.CmpDeeply(got, NRel(num, relTol), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) NRel(got interface{}, num interface{}, relTol float64, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, NRel(num, relTol), args...)
+}
+
+// NULP is a shortcut for:
+//
+//   t.CmpDeeply(got, NULP(num, ulps), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) NULP(got interface{}, num interface{}, ulps uint, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, NULP(num, ulps), args...)
+}
+
+// NaN is a shortcut for:
+//
+//   t.CmpDeeply(got, NaN(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) NaN(got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, NaN(), args...)
+}
+
 // Nil is a shortcut for:
 //
 //   t.CmpDeeply(got, Nil(), args...)
@@ -260,6 +393,16 @@ func (t *T) Not(got interface{}, expected interface{}, args ...interface{}) bool
 	return t.CmpDeeply(got, Not(expected), args...)
 }
 
+// NotNaN is a shortcut for:
+//
+//   t.CmpDeeply(got, NotNaN(), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) NotNaN(got interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, NotNaN(), args...)
+}
+
 // NotNil is a shortcut for:
 //
 //   t.CmpDeeply(got, NotNil(), args...)
@@ -314,6 +457,40 @@ func (t *T) ReAll(got interface{}, reg interface{}, capture interface{}, args ..
 	return t.CmpDeeply(got, ReAll(reg, capture), args...)
 }
 
+// Recent is a shortcut for:
+//
+//   t.CmpDeeply(got, Recent(d), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Recent(got interface{}, d time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Recent(d), args...)
+}
+
+// RoundTime is a shortcut for:
+//
+//   t.CmpDeeply(got, RoundTime(expectedTime, round), args...)
+//
+// RoundTime() optional parameter "round" is here mandatory.
+// 0 value should be passed to mimic its absence in
+// original RoundTime() call.
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) RoundTime(got interface{}, expectedTime interface{}, round time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, RoundTime(expectedTime, round), args...)
+}
+
+// SStruct is a shortcut for:
+//
+//   t.CmpDeeply(got, SStruct(model, expectedFields), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) SStruct(got interface{}, model interface{}, expectedFields StructFields, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, SStruct(model, expectedFields), args...)
+}
+
 // Set is a shortcut for:
 //
 //   t.CmpDeeply(got, Set(expectedItems...), args...)
@@ -324,6 +501,16 @@ func (t *T) Set(got interface{}, expectedItems []interface{}, args ...interface{
 	return t.CmpDeeply(got, Set(expectedItems...), args...)
 }
 
+// SetBy is a shortcut for:
+//
+//   t.CmpDeeply(got, SetBy(by, expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) SetBy(got interface{}, by interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, SetBy(by, expectedItems...), args...)
+}
+
 // Shallow is a shortcut for:
 //
 //   t.CmpDeeply(got, Shallow(expectedPtr), args...)
@@ -338,12 +525,45 @@ func (t *T) Shallow(got interface{}, expectedPtr interface{}, args ...interface{
 //
 //   t.CmpDeeply(got, Slice(model, expectedEntries), args...)
 //
+// Slice() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with Slice() to pass it.
+//
 // Returns true if the test is OK, false if it fails.
 func (t *T) Slice(got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
 	t.Helper()
 	return t.CmpDeeply(got, Slice(model, expectedEntries), args...)
 }
 
+// SliceHasPrefix is a shortcut for:
+//
+//   t.CmpDeeply(got, SliceHasPrefix(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) SliceHasPrefix(got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, SliceHasPrefix(expectedItems...), args...)
+}
+
+// SliceHasSuffix is a shortcut for:
+//
+//   t.CmpDeeply(got, SliceHasSuffix(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) SliceHasSuffix(got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, SliceHasSuffix(expectedItems...), args...)
+}
+
+// Sorted is a shortcut for:
+//
+//   t.CmpDeeply(got, Sorted(how...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Sorted(got interface{}, how []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Sorted(how...), args...)
+}
+
 // String is a shortcut for:
 //
 //   t.CmpDeeply(got, String(expected), args...)
@@ -364,6 +584,20 @@ func (t *T) Struct(got interface{}, model interface{}, expectedFields StructFiel
 	return t.CmpDeeply(got, Struct(model, expectedFields), args...)
 }
 
+// StructLike is a shortcut for:
+//
+//   t.CmpDeeply(got, StructLike(expected, tag), args...)
+//
+// StructLike() optional parameter "tag" is here mandatory.
+// "" value should be passed to mimic its absence in
+// original StructLike() call.
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) StructLike(got interface{}, expected interface{}, tag string, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, StructLike(expected, tag), args...)
+}
+
 // SubBagOf is a shortcut for:
 //
 //   t.CmpDeeply(got, SubBagOf(expectedItems...), args...)
@@ -394,6 +628,16 @@ func (t *T) SubSetOf(got interface{}, expectedItems []interface{}, args ...inter
 	return t.CmpDeeply(got, SubSetOf(expectedItems...), args...)
 }
 
+// Subsequence is a shortcut for:
+//
+//   t.CmpDeeply(got, Subsequence(expectedItems...), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Subsequence(got interface{}, expectedItems []interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Subsequence(expectedItems...), args...)
+}
+
 // SuperBagOf is a shortcut for:
 //
 //   t.CmpDeeply(got, SuperBagOf(expectedItems...), args...)
@@ -424,6 +668,19 @@ func (t *T) SuperSetOf(got interface{}, expectedItems []interface{}, args ...int
 	return t.CmpDeeply(got, SuperSetOf(expectedItems...), args...)
 }
 
+// SuperSliceOf is a shortcut for:
+//
+//   t.CmpDeeply(got, SuperSliceOf(model, expectedEntries), args...)
+//
+// SuperSliceOf() optional parameter "expectedRanges" is not available
+// here. Use CmpDeeply() with SuperSliceOf() to pass it.
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) SuperSliceOf(got interface{}, model interface{}, expectedEntries ArrayEntries, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, SuperSliceOf(model, expectedEntries), args...)
+}
+
 // TruncTime is a shortcut for:
 //
 //   t.CmpDeeply(got, TruncTime(expectedTime, trunc), args...)
@@ -438,6 +695,30 @@ func (t *T) TruncTime(got interface{}, expectedTime interface{}, trunc time.Dura
 	return t.CmpDeeply(got, TruncTime(expectedTime, trunc), args...)
 }
 
+// Unique is a shortcut for:
+//
+//   t.CmpDeeply(got, Unique(by), args...)
+//
+// Unique() optional parameter "by" is here mandatory.
+// nil value should be passed to mimic its absence in
+// original Unique() call.
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Unique(got interface{}, by interface{}, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Unique(by), args...)
+}
+
+// Within is a shortcut for:
+//
+//   t.CmpDeeply(got, Within(expectedTime, d), args...)
+//
+// Returns true if the test is OK, false if it fails.
+func (t *T) Within(got interface{}, expectedTime interface{}, d time.Duration, args ...interface{}) bool {
+	t.Helper()
+	return t.CmpDeeply(got, Within(expectedTime, d), args...)
+}
+
 // Zero is a shortcut for:
 //
 //   t.CmpDeeply(got, Zero(), args...)
@@ -14,9 +14,12 @@ import (
 // testing.T methods as well as T ones.
 type T struct {
 	*testing.T
+	Config ContextConfig // defaults to DefaultContextConfig
 }
 
-// NewT returns a new T instance. Typically used as:
+// NewT returns a new T instance. "config" is optional. If passed,
+// it is used instead of DefaultContextConfig to render tests
+// failures. Typically used as:
 //
 //   type Record struct {
 //     Id        uint64
@@ -49,32 +52,49 @@ type T struct {
 //       }
 //     }
 //   }
-func NewT(t *testing.T) *T {
-	return &T{
-		T: t,
+func NewT(t *testing.T, config ...ContextConfig) *T {
+	switch len(config) {
+	case 0:
+		return &T{
+			T:      t,
+			Config: DefaultContextConfig,
+		}
+
+	case 1:
+		return &T{
+			T:      t,
+			Config: config[0],
+		}
+
+	default:
+		panic("usage: NewT(*testing.T[, ContextConfig])")
 	}
 }
 
 // CmpDeeply is shortcut for:
 //
 //   CmpDeeply(t.T, got, expected, args...)
+//
+// except that t.Config is used instead of DefaultContextConfig to
+// render the failure, if any.
 func (t *T) CmpDeeply(got, expected interface{}, args ...interface{}) bool {
 	t.Helper()
-	return CmpDeeply(t.T, got, expected, args...)
+	return cmpDeeply(NewContextWithConfig("DATA", t.Config),
+		t.T, got, expected, args...)
 }
 
 // True is shortcut for:
 //
-//   CmpDeeply(t.T, got, true, args...)
+//   t.CmpDeeply(got, true, args...)
 func (t *T) True(got interface{}, args ...interface{}) bool {
 	t.Helper()
-	return CmpDeeply(t.T, got, true, args...)
+	return t.CmpDeeply(got, true, args...)
 }
 
 // False is shortcut for:
 //
-//   CmpDeeply(t.T, got, false, args...)
+//   t.CmpDeeply(got, false, args...)
 func (t *T) False(got interface{}, args ...interface{}) bool {
 	t.Helper()
-	return CmpDeeply(t.T, got, false, args...)
+	return t.CmpDeeply(got, false, args...)
 }
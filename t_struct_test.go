@@ -13,6 +13,17 @@ import (
 	. "github.com/maxatome/go-testdeep"
 )
 
+func TestNewT(t *testing.T) {
+	tt := NewT(t)
+	CmpDeeply(t, tt.Config, DefaultContextConfig)
+
+	tt = NewT(t, ContextConfig{MaxItems: 12})
+	CmpDeeply(t, tt.Config, ContextConfig{MaxItems: 12})
+
+	checkPanic(t, func() { NewT(t, ContextConfig{}, ContextConfig{}) },
+		"usage: NewT(")
+}
+
 func ExampleT_True() {
 	t := NewT(&testing.T{})
 
@@ -12,14 +12,34 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/maxatome/go-testdeep"
 )
 
+func ExampleT_After() {
+	t := NewT(&testing.T{})
+
+	start := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := start.Add(time.Minute)
+
+	ok := t.After(got, start, "checks %v is after %v", got, start)
+	fmt.Println(ok)
+
+	ok = t.After(start, got, "checks %v is after %v", start, got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_All() {
 	t := NewT(&testing.T{})
 
@@ -163,6 +183,32 @@ func ExampleT_ArrayEach_typedSlice() {
 	// true
 }
 
+func ExampleT_AtLeast() {
+	t := NewT(&testing.T{})
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := t.AtLeast(got, Gt(10), 2,
+		"checks at least 2 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleT_AtMost() {
+	t := NewT(&testing.T{})
+
+	got := map[string]int{"a": 12, "b": 3, "c": 20}
+
+	ok := t.AtMost(got, Lt(10), 1,
+		"checks at most 1 value of %v is < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleT_Bag() {
 	t := NewT(&testing.T{})
 
@@ -198,6 +244,65 @@ func ExampleT_Bag() {
 	// true
 }
 
+func ExampleT_BagBy() {
+	t := NewT(&testing.T{})
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 3, Name: "Charlie"},
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+	charlie := Record{ID: 3, Name: "Charlie"}
+
+	// Matches as all records are present, in any order
+	ok := t.BagBy(got, "ID", []interface{}{alice, bob, charlie},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #3 is not expected
+	ok = t.BagBy(got, "ID", []interface{}{alice, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #2 differs
+	bobby := Record{ID: 2, Name: "Bobby"}
+	ok = t.BagBy(got, "ID", []interface{}{alice, bobby, charlie},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
+func ExampleT_Before() {
+	t := NewT(&testing.T{})
+
+	deadline := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := deadline.Add(-time.Minute)
+
+	ok := t.Before(got, deadline,
+		"checks %v is before %v", got, deadline)
+	fmt.Println(ok)
+
+	ok = t.Before(deadline, deadline,
+		"checks %v is before %v", deadline, deadline)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_Between() {
 	t := NewT(&testing.T{})
 
@@ -232,6 +337,32 @@ func ExampleT_Between() {
 	// false
 }
 
+func ExampleT_Between_ordered() {
+	t := NewT(&testing.T{})
+
+	// Strings are compared lexically
+	ok := t.Between("abc", "abb", "abd", BoundsInIn,
+		`checks "abc" is in ["abb" .. "abd"]`)
+	fmt.Println(ok)
+
+	// Integers of different sizes can be compared
+	got := int64(156)
+	ok = t.Between(got, 154, 156, BoundsInIn,
+		"checks %v is in [154 .. 156]", got)
+	fmt.Println(ok)
+
+	// Types with a Cmp method, as *big.Int
+	gotBig := big.NewInt(156)
+	ok = t.Between(gotBig, big.NewInt(154), big.NewInt(156), BoundsInIn,
+		"checks %v is in [154 .. 156]", gotBig)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// true
+}
+
 func ExampleT_Cap() {
 	t := NewT(&testing.T{})
 
@@ -326,6 +457,48 @@ func ExampleT_Contains_error() {
 	// true
 }
 
+func ExampleT_Count() {
+	t := NewT(&testing.T{})
+
+	got := []int{12, 3, 20, 1, 15}
+
+	ok := t.Count(got, Gt(10), 3,
+		"checks 3 items of %v are > 10", got)
+	fmt.Println(ok)
+
+	ok = t.Count(got, Lt(10), Between(1, 3),
+		"checks 1 to 3 items of %v are < 10", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
+func ExampleT_Finite() {
+	t := NewT(&testing.T{})
+
+	got := 12.5
+
+	ok := t.Finite(got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.Inf(1)
+
+	ok = t.Finite(got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = t.Finite(got, "checks %v is finite", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+	// false
+}
+
 func ExampleT_Gt() {
 	t := NewT(&testing.T{})
 
@@ -428,6 +601,26 @@ func ExampleT_HasSuffix_error() {
 	// true
 }
 
+func ExampleT_Inf() {
+	t := NewT(&testing.T{})
+
+	got := math.Inf(-1)
+
+	ok := t.Inf(got, -1, "checks %v is -Inf", got)
+	fmt.Println(ok)
+
+	ok = t.Inf(got, 0, "checks %v is an infinity", got)
+	fmt.Println(ok)
+
+	ok = t.Inf(got, 1, "checks %v is +Inf", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Isa() {
 	t := NewT(&testing.T{})
 
@@ -661,6 +854,57 @@ func ExampleT_MapEach_typedMap() {
 	// true
 }
 
+func ExampleT_MapEachEntry() {
+	t := NewT(&testing.T{})
+
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	got := map[int]User{
+		1: {ID: 1, Name: "Alice"},
+		2: {ID: 2, Name: "Bob"},
+	}
+
+	ok := t.MapEachEntry(got, func(id int, user User) bool { return user.ID == id },
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	got[3] = User{ID: 4, Name: "Charlie"}
+	ok = t.MapEachEntry(got, func(id int, user User) (bool, string) {
+		if user.ID != id {
+			return false, "ID field must be the same as the key"
+		}
+		return true, ""
+	},
+		"checks each user ID of map %v is its key", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_MapEachKey() {
+	t := NewT(&testing.T{})
+
+	got := map[string]int{"id_1": 12, "id_2": 42, "id_3": 89}
+
+	ok := t.MapEachKey(got, Re(`^id_\d+$`),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	got["id_x"] = 0
+	ok = t.MapEachKey(got, Re(`^id_\d+$`),
+		"checks each key of map %v matches id_NUM", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_N() {
 	t := NewT(&testing.T{})
 
@@ -674,6 +918,62 @@ func ExampleT_N() {
 	// true
 }
 
+func ExampleT_NRel() {
+	t := NewT(&testing.T{})
+
+	got := 1000.0000001
+
+	ok := t.NRel(got, 1000.0, 1e-9,
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	got = 1000.001
+
+	ok = t.NRel(got, 1000.0, 1e-9,
+		"checks %v = 1000 ± 1e-9 relative", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_NULP() {
+	t := NewT(&testing.T{})
+
+	a, b := 0.1, 0.2
+	got := a + b
+
+	ok := t.NULP(got, 0.3, 1,
+		"checks %v = 0.3 ± 1 ULP", got)
+	fmt.Println(ok)
+
+	ok = t.NULP(got, 0.3, 0,
+		"checks %v = 0.3 exactly", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_NaN() {
+	t := NewT(&testing.T{})
+
+	got := math.NaN()
+
+	ok := t.NaN(got, "checks %v is NaN", got)
+	fmt.Println(ok)
+
+	// NaN is not equal to NaN, even using NaN value
+	ok = t.CmpDeeply(got, math.NaN())
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_Nil() {
 	t := NewT(&testing.T{})
 
@@ -773,6 +1073,24 @@ func ExampleT_Not() {
 	// false
 }
 
+func ExampleT_NotNaN() {
+	t := NewT(&testing.T{})
+
+	got := 12.5
+
+	ok := t.NotNaN(got, "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	got = math.NaN()
+
+	ok = t.NotNaN(got, "checks %v is not NaN", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_NotNil() {
 	t := NewT(&testing.T{})
 
@@ -1049,6 +1367,93 @@ func ExampleT_ReAll_compiledCaptureComplex() {
 	// false
 }
 
+func ExampleT_Recent() {
+	t := NewT(&testing.T{})
+
+	// Typically the creation date of a freshly created record
+	got := time.Now()
+
+	ok := t.Recent(got, time.Second,
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	got = got.Add(-time.Hour)
+
+	ok = t.Recent(got, time.Second,
+		"checks %v is within 1s of now", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_RoundTime() {
+	t := NewT(&testing.T{})
+
+	got := time.Date(2018, time.May, 1, 12, 45, 53, 600000000, time.UTC)
+
+	// Compare dates rounded to the second
+	expected := time.Date(2018, time.May, 1, 12, 45, 54, 0, time.UTC)
+	ok := t.RoundTime(got, expected, time.Second,
+		"checks date %v, rounded to the second", got)
+	fmt.Println(ok)
+
+	// Truncation does not give the same result
+	ok = t.CmpDeeply(got, TruncTime(expected, time.Second),
+		"checks date %v, truncated to the second", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_SStruct() {
+	t := NewT(&testing.T{})
+
+	type Person struct {
+		Name        string
+		Age         int
+		NumChildren int
+	}
+
+	got := Person{
+		Name:        "Foobar",
+		Age:         42,
+		NumChildren: 0,
+	}
+
+	// NumChildren is zero in SStruct() call, so it is checked too
+	ok := t.SStruct(got, Person{Name: "Foobar"}, StructFields{
+		"Age": Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Model can be empty
+	ok = t.SStruct(got, Person{}, StructFields{
+		"Name": "Foobar",
+		"Age":  Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// NumChildren is not zero, but absent from SStruct() call
+	got.NumChildren = 3
+	ok = t.SStruct(&got, &Person{}, StructFields{
+		"Name": "Foobar",
+		"Age":  Between(40, 50),
+	},
+		"checks %v is the right Person")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Set() {
 	t := NewT(&testing.T{})
 
@@ -1076,6 +1481,39 @@ func ExampleT_Set() {
 	// true
 }
 
+func ExampleT_SetBy() {
+	t := NewT(&testing.T{})
+
+	type Record struct {
+		ID   int
+		Name string
+	}
+
+	got := []Record{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 1, Name: "Alice"},
+	}
+
+	alice := Record{ID: 1, Name: "Alice"}
+	bob := Record{ID: 2, Name: "Bob"}
+
+	// Matches as all records are present, ignoring duplicates
+	ok := t.SetBy(got, "ID", []interface{}{alice, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Does not match as record #1 differs
+	alicia := Record{ID: 1, Name: "Alicia"}
+	ok = t.SetBy(got, "ID", []interface{}{alicia, bob},
+		"checks all records are present, paired by ID")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_Shallow() {
 	t := NewT(&testing.T{})
 
@@ -1132,6 +1570,90 @@ func ExampleT_Slice_typedSlice() {
 	// true
 }
 
+func ExampleT_SliceHasPrefix() {
+	t := NewT(&testing.T{})
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := t.SliceHasPrefix(got, []interface{}{"start", "open"},
+		"checks %v starts with start & open", got)
+	fmt.Println(ok)
+
+	ok = t.SliceHasPrefix(got, []interface{}{"start", Not("open")},
+		"checks %v second item is not open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_SliceHasSuffix() {
+	t := NewT(&testing.T{})
+
+	got := []string{"start", "open", "read", "close", "stop"}
+
+	ok := t.SliceHasSuffix(got, []interface{}{"close", "stop"},
+		"checks %v ends with close & stop", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleT_Sorted() {
+	t := NewT(&testing.T{})
+
+	got := []int{1, 3, 3, 7}
+
+	ok := t.Sorted(got, nil, "checks %v is sorted", got)
+	fmt.Println(ok)
+
+	ok = t.Sorted(got, []interface{}{-1}, "checks %v is sorted in descending order", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_Sorted_fields() {
+	t := NewT(&testing.T{})
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := []Person{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 42},
+		{Name: "Bob", Age: 20},
+	}
+
+	ok := t.Sorted(got, []interface{}{"Name", "-Age"},
+		"checks persons are sorted by name, then by age in descending order")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleT_Sorted_lessFunc() {
+	t := NewT(&testing.T{})
+
+	got := []string{"c", "ab", "xyz"}
+
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+
+	ok := t.Sorted(got, []interface{}{byLen},
+		"checks %v is sorted by string length", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
 func ExampleT_String() {
 	t := NewT(&testing.T{})
 
@@ -1215,6 +1737,52 @@ func ExampleT_Struct() {
 	// true
 }
 
+func ExampleT_StructLike() {
+	t := NewT(&testing.T{})
+
+	type PersonDTO struct {
+		Name     string `json:"name"`
+		Age      int    `json:"age"`
+		Password string `json:"-"`
+	}
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	got := PersonDTO{
+		Name:     "Foobar",
+		Age:      42,
+		Password: "secret",
+	}
+
+	// Types differ, but fields are matched by name: Password is extra
+	person := Person{Name: "Foobar", Age: 42}
+	ok := t.StructLike(got, person, "",
+		"checks %v is like a Person")
+	fmt.Println(ok)
+
+	// Using a map and json tags, Password is ignored
+	entries := map[string]interface{}{
+		"name": "Foobar",
+		"age":  Between(40, 45),
+	}
+	ok = t.StructLike(got, entries, "json",
+		"checks %v is like the map, using json tags")
+	fmt.Println(ok)
+
+	// Person fields are named Name & Age, not name & age
+	ok = t.StructLike(got, person, "json",
+		"checks %v is like a Person, using json tags")
+	fmt.Println(ok)
+
+	// Output:
+	// false
+	// true
+	// false
+}
+
 func ExampleT_SubBagOf() {
 	t := NewT(&testing.T{})
 
@@ -1295,6 +1863,24 @@ func ExampleT_SubSetOf() {
 	// true
 }
 
+func ExampleT_Subsequence() {
+	t := NewT(&testing.T{})
+
+	got := []string{"start", "open", "read", "read", "close", "stop"}
+
+	ok := t.Subsequence(got, []interface{}{"open", "read", "close"},
+		"checks %v contains open, read & close in this order", got)
+	fmt.Println(ok)
+
+	ok = t.Subsequence(got, []interface{}{"close", "open"},
+		"checks %v contains close then open", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
 func ExampleT_SuperBagOf() {
 	t := NewT(&testing.T{})
 
@@ -1365,6 +1951,24 @@ func ExampleT_SuperSetOf() {
 	// true
 }
 
+func ExampleT_SuperSliceOf() {
+	t := NewT(&testing.T{})
+
+	got := []int{42, 58, 26, 12, 7}
+
+	ok := t.SuperSliceOf(got, []int{42}, ArrayEntries{1: Gt(50)},
+		"checks first items of slice %v", got)
+	fmt.Println(ok)
+
+	ok = t.SuperSliceOf(got, []int{42}, ArrayEntries{-1: 7},
+		"checks first & last items of slice %v", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+}
+
 func ExampleT_TruncTime() {
 	t := NewT(&testing.T{})
 
@@ -1402,6 +2006,72 @@ func ExampleT_TruncTime() {
 	// true
 }
 
+func ExampleT_Unique() {
+	t := NewT(&testing.T{})
+
+	got := []int{1, 3, 2, 7}
+
+	ok := t.Unique(got, nil, "checks %v has no duplicates", got)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+}
+
+func ExampleT_Unique_key() {
+	t := NewT(&testing.T{})
+
+	type Person struct {
+		ID   int
+		Name string
+	}
+
+	got := []Person{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "bob"},
+	}
+
+	ok := t.Unique(got, "ID", "checks IDs are unique")
+	fmt.Println(ok)
+
+	lowerName := func(p Person) string { return strings.ToLower(p.Name) }
+
+	ok = t.Unique(got, lowerName,
+		"checks names are unique, case insensitively")
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleT_Within() {
+	t := NewT(&testing.T{})
+
+	expected := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+	got := expected.Add(3 * time.Second)
+
+	ok := t.Within(got, expected, 5*time.Second,
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Locations do not matter
+	paris := time.FixedZone("CET", 3600)
+	ok = t.Within(got.In(paris), expected, 5*time.Second,
+		"checks %v is within 5s of %v", got, expected)
+	fmt.Println(ok)
+
+	ok = t.Within(got, expected, time.Second,
+		"checks %v is within 1s of %v", got, expected)
+	fmt.Println(ok)
+
+	// Output:
+	// true
+	// true
+	// false
+}
+
 func ExampleT_Zero() {
 	t := NewT(&testing.T{})
 
@@ -9,6 +9,8 @@ package testdeep
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 type tdAll struct {
@@ -19,6 +21,11 @@ var _ TestDeep = &tdAll{}
 
 // All operator compares data against several expected values. During
 // a match, all of them have to match to succeed.
+//
+// In case of failure, all the expected values that did not match are
+// reported, not only the first one. The reasons why they did not
+// match are limited to ContextConfig.MaxItems (or 10 if MaxItems is
+// 0).
 func All(expectedValues ...interface{}) TestDeep {
 	return &tdAll{
 		tdList: newList(expectedValues...),
@@ -26,26 +33,67 @@ func All(expectedValues ...interface{}) TestDeep {
 }
 
 func (a *tdAll) Match(ctx Context, got reflect.Value) (err *Error) {
+	var (
+		origErrs []*Error
+		parts    []string
+		omitted  int
+	)
+	maxErrors := maxListErrors(ctx)
+
 	for idx, item := range a.items {
+		// No need to build the path in a boolean Context
+		if ctx.booleanError {
+			if deepValueEqual(ctx, got, item) != nil {
+				return booleanError
+			}
+			continue
+		}
+
+		// Enough reasons, only check whether it matches
+		if len(origErrs) == maxErrors {
+			if deepValueEqual(ctx.boolean(), got, item) != nil {
+				parts = append(parts, strconv.Itoa(idx+1))
+				omitted++
+			}
+			continue
+		}
+
 		origErr := deepValueEqual(
 			ctx.AddDepth(fmt.Sprintf("<All#%d/%d>", idx+1, len(a.items))),
 			got, item)
 		if origErr != nil {
-			if ctx.booleanError {
-				return booleanError
-			}
-			err = &Error{
-				Context:  ctx,
-				Message:  fmt.Sprintf("compared (part %d of %d)", idx+1, len(a.items)),
-				Got:      got,
-				Expected: item,
-				Location: a.GetLocation(),
-			}
+			if err == nil {
+				err = &Error{
+					Context:  ctx,
+					Message:  fmt.Sprintf("compared (part %d of %d)", idx+1, len(a.items)),
+					Got:      got,
+					Expected: item,
+					Location: a.GetLocation(),
+				}
 
-			if item.IsValid() && item.Type().Implements(testDeeper) {
-				err.Origin = origErr
+				if item.IsValid() && item.Type().Implements(testDeeper) {
+					err.Origin = origErr
+				}
 			}
-			return
+
+			origErrs = append(origErrs, origErr)
+			parts = append(parts, strconv.Itoa(idx+1))
+		}
+	}
+
+	// Several parts failed, report all of them
+	if len(parts) > 1 {
+		err = &Error{
+			Context: ctx,
+			Message: fmt.Sprintf("compared (parts %s of %d)",
+				strings.Join(parts, ", "), len(a.items)),
+			Summary: tdListResult{
+				Got:      got,
+				Expected: a,
+				Errors:   origErrs,
+				Omitted:  omitted,
+			},
+			Location: a.GetLocation(),
 		}
 	}
 	return
@@ -57,6 +57,32 @@ func TestAll(t *testing.T) {
 			},
 		})
 
+	// All failing parts are reported
+	checkError(t, 6, All(5, 6, Between(7, 9)), expectedError{
+		Message: mustBe("compared (parts 1, 3 of 3)"),
+		Path:    mustBe("DATA"),
+		Summary: mustMatch(`^     got: \(int\) 6
+expected: All\(\(int\) 5,
+              \(int\) 6,
+              7 ≤ got ≤ 9\)
+ reasons: DATA<All#1/3>: values differ \(got: \(int\) 6, expected: \(int\) 5\)
+          DATA<All#3/3>: values differ \(got: 6, expected: 7 ≤ got ≤ 9\) \[Between at td_all_test\.go:\d+\]\z`),
+	})
+
+	// Reasons are capped, remaining parts being still compared
+	parts := make([]interface{}, 12)
+	for i := range parts {
+		parts[i] = i + 1
+	}
+	parts[10] = 0
+	checkError(t, 0, All(parts...), expectedError{
+		Message: mustBe("compared (parts 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12 of 12)"),
+		Path:    mustBe("DATA"),
+		Summary: mustMatch(`(?s) reasons: DATA<All#1/12>: .*
+          DATA<All#10/12>: values differ \(got: \(int\) 0, expected: \(int\) 10\)
+          …\(1 more reason\)\z`),
+	})
+
 	//
 	// String
 	equalStr(t, All(6).String(), "All((int) 6)")
@@ -7,6 +7,7 @@
 package testdeep
 
 import (
+	"fmt"
 	"reflect"
 )
 
@@ -18,6 +19,10 @@ var _ TestDeep = &tdAny{}
 
 // Any operator compares data against several expected values. During
 // a match, at least one of them has to match to succeed.
+//
+// In case of failure, the reason why each expected value did not
+// match is reported, limited to ContextConfig.MaxItems reasons (or 10
+// if MaxItems is 0).
 func Any(expectedValues ...interface{}) TestDeep {
 	return &tdAny{
 		tdList: newList(expectedValues...),
@@ -25,20 +30,45 @@ func Any(expectedValues ...interface{}) TestDeep {
 }
 
 func (a *tdAny) Match(ctx Context, got reflect.Value) *Error {
-	for _, item := range a.items {
-		if deepValueEqualOK(got, item) {
-			return nil
+	if ctx.booleanError {
+		for _, item := range a.items {
+			if deepValueEqual(ctx, got, item) == nil {
+				return nil
+			}
 		}
+		return booleanError
 	}
 
-	if ctx.booleanError {
-		return booleanError
+	// Each alternative is compared only once, recording why it failed
+	maxErrors := maxListErrors(ctx)
+	res := tdListResult{
+		Got:      got,
+		Expected: a,
 	}
+	for idx, item := range a.items {
+		// Enough reasons, only check whether it matches
+		if len(res.Errors) == maxErrors {
+			if deepValueEqual(ctx.boolean(), got, item) == nil {
+				return nil
+			}
+			res.Omitted++
+			continue
+		}
+
+		newCtx := ctx.AddDepth(fmt.Sprintf("<Any#%d/%d>", idx+1, len(a.items)))
+		newCtx.visited = nil
+
+		err := deepValueEqual(newCtx, got, item)
+		if err == nil {
+			return nil
+		}
+		res.Errors = append(res.Errors, err)
+	}
+
 	return &Error{
 		Context:  ctx,
 		Message:  "comparing with Any",
-		Got:      got,
-		Expected: a,
+		Summary:  res,
 		Location: a.GetLocation(),
 	}
 }
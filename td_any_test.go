@@ -17,26 +17,65 @@ func TestAny(t *testing.T) {
 	checkOK(t, nil, Any(5, 6, 7, nil))
 
 	checkError(t, 6, Any(5), expectedError{
-		Message:  mustBe("comparing with Any"),
-		Path:     mustBe("DATA"),
-		Got:      mustBe("(int) 6"),
-		Expected: mustBe("Any((int) 5)"),
+		Message: mustBe("comparing with Any"),
+		Path:    mustBe("DATA"),
+		Summary: mustBe(`     got: (int) 6
+expected: Any((int) 5)
+ reasons: DATA<Any#1/1>: values differ (got: (int) 6, expected: (int) 5)`),
 	})
 
 	checkError(t, 6, Any(nil), expectedError{
-		Message:  mustBe("comparing with Any"),
-		Path:     mustBe("DATA"),
-		Got:      mustBe("(int) 6"),
-		Expected: mustBe("Any(nil)"),
+		Message: mustBe("comparing with Any"),
+		Path:    mustBe("DATA"),
+		Summary: mustBe(`     got: (int) 6
+expected: Any(nil)
+ reasons: DATA<Any#1/1>: values differ (got: (int) 6, expected: nil)`),
 	})
 
 	checkError(t, nil, Any(6), expectedError{
-		Message:  mustBe("comparing with Any"),
-		Path:     mustBe("DATA"),
-		Got:      mustBe("nil"),
-		Expected: mustBe("Any((int) 6)"),
+		Message: mustBe("comparing with Any"),
+		Path:    mustBe("DATA"),
+		Summary: mustBe(`     got: nil
+expected: Any((int) 6)
+ reasons: DATA<Any#1/1>: values differ (got: nil, expected: (int) 6)`),
 	})
 
+	// Each alternative is explained
+	checkError(t, 6, Any(5, Between(7, 9), "foo"), expectedError{
+		Message: mustBe("comparing with Any"),
+		Path:    mustBe("DATA"),
+		Summary: mustMatch(`^     got: \(int\) 6
+expected: Any\(\(int\) 5,
+              7 ≤ got ≤ 9,
+              \(string\) \(len=3\) "foo"\)
+ reasons: DATA<Any#1/3>: values differ \(got: \(int\) 6, expected: \(int\) 5\)
+          DATA<Any#2/3>: values differ \(got: 6, expected: 7 ≤ got ≤ 9\) \[Between at td_any_test\.go:\d+\]
+          DATA<Any#3/3>: type mismatch \(got: int, expected: string\)\z`),
+	})
+
+	// Reasons are capped, remaining alternatives being still compared
+	alternatives := make([]interface{}, 12)
+	for i := range alternatives {
+		alternatives[i] = i + 1
+	}
+	checkError(t, 0, Any(alternatives...), expectedError{
+		Message: mustBe("comparing with Any"),
+		Path:    mustBe("DATA"),
+		Summary: mustMatch(`(?s) reasons: DATA<Any#1/12>: .*
+          DATA<Any#10/12>: values differ \(got: \(int\) 0, expected: \(int\) 10\)
+          …\(2 more reasons\)\z`),
+	})
+	checkOK(t, 12, Any(alternatives...))
+
+	// Values are reduced in compact errors
+	checkError(t, "0123456789012345678901234567890123456789012345678901234567890123456789",
+		Any("foo"), expectedError{
+			Message: mustBe("comparing with Any"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain(
+				` reasons: DATA<Any#1/1>: values differ (got: (string) (len=70) "012345678901234567890123456789012345678901234…, expected: (string) (len=3) "foo")`),
+		})
+
 	//
 	// String
 	equalStr(t, Any(6).String(), "Any((int) 6)")
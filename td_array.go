@@ -10,23 +10,107 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 type tdArray struct {
 	Base
 	expectedModel   reflect.Value
 	expectedEntries []reflect.Value
-	isPtr           bool
+	// true for each expectedEntries item not explicitly expected, so
+	// zero, that a windowEntries one can override
+	implicitEntries []bool
+	// entries resolved against the compared data length (negative
+	// indexes and ranges), sorted by key
+	windowEntries []arrayWindowEntry
+	isPtr         bool
+	isSuper       bool
 }
 
 var _ TestDeep = &tdArray{}
 
+// arrayWindowEntry is an ArrayEntries entry whose key is a negative
+// index, or an ArrayRanges entry.
+type arrayWindowEntry struct {
+	key      ArrayRange
+	isRange  bool
+	expected reflect.Value
+}
+
 // ArrayEntries allows to pass array or slice entries to check in
-// functions Array and Slice. It is a map whose each key is the item
-// index and the corresponding value the expected item value (which
-// can be a TestDeep operator as well as a zero value.)
+// functions Array, Slice and SuperSliceOf. It is a map whose each key
+// is the item index and the corresponding value the expected item
+// value (which can be a TestDeep operator as well as a zero value.)
+//
+// A negative index is counted from the end of the compared data, so
+// -1 is its last item.
 type ArrayEntries map[int]interface{}
 
+// ArrayRanges allows to pass windows of array or slice items to check
+// in functions Array, Slice and SuperSliceOf, as an optional
+// parameter following ArrayEntries. It is a map whose each key is an
+// ArrayRange and the corresponding value the expected value of each
+// item of this window of the compared data:
+//   Slice([]string{"first"},
+//     ArrayEntries{-1: "last"},
+//     ArrayRanges{ArrayRange{1, -2}: Ignore()})
+type ArrayRanges map[ArrayRange]interface{}
+
+// ArrayRange is an ArrayRanges key designating all the items whose
+// index is between From and To, both included. As for ArrayEntries
+// keys, a negative bound is counted from the end of the compared
+// data, so ArrayRange{0, -1} designates all the items.
+//
+// A window whose resolved From is greater than its resolved To is
+// empty, so matches nothing. For example ArrayRange{1, -1} is empty
+// when compared data has only one item.
+type ArrayRange struct {
+	From int
+	To   int
+}
+
+// String returns the ArrayRange as "From..To".
+func (r ArrayRange) String() string {
+	return fmt.Sprintf("%d..%d", r.From, r.To)
+}
+
+// less returns true if r is before "other", bounds counted from the
+// start coming before bounds counted from the end.
+func (r ArrayRange) less(other ArrayRange) bool {
+	if r.From != other.From {
+		if (r.From < 0) != (other.From < 0) {
+			return other.From < 0
+		}
+		return r.From < other.From
+	}
+	if (r.To < 0) != (other.To < 0) {
+		return other.To < 0
+	}
+	return r.To < other.To
+}
+
+// resolve returns r bounds resolved against "length". It returns
+// false if the window is not empty and does not fit in length, the
+// int being the first out of range bound as originally specified.
+func (r ArrayRange) resolve(length int) (from, to int, ok bool, badBound int) {
+	from, to = r.From, r.To
+	if from < 0 {
+		from += length
+	}
+	if to < 0 {
+		to += length
+	}
+
+	switch {
+	case from > to: // empty window
+	case from < 0:
+		return from, to, false, r.From
+	case to >= length:
+		return from, to, false, r.To
+	}
+	return from, to, true, 0
+}
+
 // Array operator compares the contents of an array or a pointer on an
 // array against the non-zero values of "model" (if any) and the
 // values of "expectedEntries".
@@ -34,32 +118,64 @@ type ArrayEntries map[int]interface{}
 // "model" must be the same type as compared data.
 //
 // "expectedEntries" can be nil, if no zero entries are expected and
-// no TestDeep operator are involved.
+// no TestDeep operator are involved. See ArrayEntries for negative
+// indexes and ArrayRanges for the optional "expectedRanges".
 //
 // TypeBehind method returns the reflect.Type of "model".
-func Array(model interface{}, expectedEntries ArrayEntries) TestDeep {
+func Array(model interface{}, expectedEntries ArrayEntries, expectedRanges ...ArrayRanges) TestDeep {
 	vmodel := reflect.ValueOf(model)
 
 	a := tdArray{
 		Base: NewBase(3),
 	}
 
-	switch vmodel.Kind() {
-	case reflect.Ptr:
-		vmodel = vmodel.Elem()
-		if vmodel.Kind() != reflect.Array {
-			break
+	if len(expectedRanges) <= 1 {
+		switch vmodel.Kind() {
+		case reflect.Ptr:
+			vmodel = vmodel.Elem()
+			if vmodel.Kind() != reflect.Array {
+				break
+			}
+			a.isPtr = true
+			fallthrough
+
+		case reflect.Array:
+			a.expectedModel = vmodel
+			a.populateExpectedEntries(expectedEntries, expectedRanges)
+			return &a
 		}
-		a.isPtr = true
-		fallthrough
+	}
+
+	panic("usage: Array(ARRAY|&ARRAY, EXPECTED_ENTRIES[, EXPECTED_RANGES])")
+}
+
+func newSlice(model interface{}, expectedEntries ArrayEntries, expectedRanges []ArrayRanges, isSuper bool) *tdArray {
+	vmodel := reflect.ValueOf(model)
+
+	a := tdArray{
+		Base:    NewBase(4),
+		isSuper: isSuper,
+	}
+
+	if len(expectedRanges) <= 1 {
+		switch vmodel.Kind() {
+		case reflect.Ptr:
+			vmodel = vmodel.Elem()
+			if vmodel.Kind() != reflect.Slice {
+				break
+			}
+			a.isPtr = true
+			fallthrough
 
-	case reflect.Array:
-		a.expectedModel = vmodel
-		a.populateExpectedEntries(expectedEntries)
-		return &a
+		case reflect.Slice:
+			a.expectedModel = vmodel
+			a.populateExpectedEntries(expectedEntries, expectedRanges)
+			return &a
+		}
 	}
 
-	panic("usage: Array(ARRAY|&ARRAY, EXPECTED_ENTRIES)")
+	panic(fmt.Sprintf("usage: %s(SLICE|&SLICE, EXPECTED_ENTRIES[, EXPECTED_RANGES])",
+		a.GetLocation().Func))
 }
 
 // Slice operator compares the contents of a slice or a pointer on a
@@ -69,47 +185,116 @@ func Array(model interface{}, expectedEntries ArrayEntries) TestDeep {
 // "model" must be the same type as compared data.
 //
 // "expectedEntries" can be nil, if no zero entries are expected and
-// no TestDeep operator are involved.
+// no TestDeep operator are involved. See ArrayEntries for negative
+// indexes and ArrayRanges for the optional "expectedRanges".
+//
+// Each compared slice item has to be expected, by "model" or by
+// "expectedEntries". See SuperSliceOf operator to ignore the
+// extra trailing items.
 //
 // TypeBehind method returns the reflect.Type of "model".
-func Slice(model interface{}, expectedEntries ArrayEntries) TestDeep {
-	vmodel := reflect.ValueOf(model)
+func Slice(model interface{}, expectedEntries ArrayEntries, expectedRanges ...ArrayRanges) TestDeep {
+	return newSlice(model, expectedEntries, expectedRanges, false)
+}
 
-	a := tdArray{
-		Base: NewBase(3),
-	}
+// SuperSliceOf operator compares the contents of a slice or a pointer
+// on a slice against the non-zero values of "model" (if any) and the
+// values of "expectedEntries", as Slice operator does. But contrary
+// to Slice, compared slice can contain extra trailing items, not
+// expected by "model" nor "expectedEntries":
+//   CmpDeeply(t, []int{1, 2, 3, 4, 5},
+//     SuperSliceOf([]int{1, 2}, ArrayEntries{-1: 5})) // succeeds
+//
+// "model" must be the same type as compared data. See ArrayRanges
+// for the optional "expectedRanges".
+//
+// TypeBehind method returns the reflect.Type of "model".
+func SuperSliceOf(model interface{}, expectedEntries ArrayEntries, expectedRanges ...ArrayRanges) TestDeep {
+	return newSlice(model, expectedEntries, expectedRanges, true)
+}
 
-	switch vmodel.Kind() {
-	case reflect.Ptr:
-		vmodel = vmodel.Elem()
-		if vmodel.Kind() != reflect.Slice {
-			break
-		}
-		a.isPtr = true
-		fallthrough
+func (a *tdArray) expectedValue(key interface{}, expectedValue interface{}) reflect.Value {
+	elemType := a.expectedModel.Type().Elem()
 
-	case reflect.Slice:
-		a.expectedModel = vmodel
-		a.populateExpectedEntries(expectedEntries)
-		return &a
+	if expectedValue == nil {
+		// change to a typed nil
+		vexpectedValue, ok := nilValue(elemType)
+		if !ok {
+			panic(fmt.Sprintf(
+				"expected value of #%v cannot be nil as items type is %s",
+				key,
+				elemType))
+		}
+		return vexpectedValue
 	}
 
-	panic("usage: Slice(SLICE|&SLICE, EXPECTED_ENTRIES)")
+	vexpectedValue := reflect.ValueOf(expectedValue)
+
+	if _, ok := expectedValue.(TestDeep); !ok {
+		if !vexpectedValue.Type().AssignableTo(elemType) {
+			panic(fmt.Sprintf(
+				"type %s of #%v expected value differs from %s contents (%s)",
+				vexpectedValue.Type(),
+				key,
+				ternStr(a.expectedModel.Kind() == reflect.Slice, "slice", "array"),
+				elemType))
+		}
+	}
+	return vexpectedValue
 }
 
-func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries) {
+func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries, expectedRanges []ArrayRanges) {
 	var maxLength, numEntries int
 
+	if a.expectedModel.Kind() == reflect.Array {
+		maxLength = a.expectedModel.Len()
+	} else {
+		maxLength = -1
+	}
+
+	addWindow := func(window arrayWindowEntry, key, expectedValue interface{}) {
+		if maxLength >= 0 {
+			if _, _, ok, badBound := window.key.resolve(maxLength); !ok {
+				panic(fmt.Sprintf(
+					"array length is %d, so cannot have #%d expected index",
+					maxLength,
+					badBound))
+			}
+		}
+
+		window.expected = a.expectedValue(key, expectedValue)
+		a.windowEntries = append(a.windowEntries, window)
+	}
+
+	indexes := make(map[int]interface{}, len(expectedEntries))
+
 	maxIndex := -1
-	for index := range expectedEntries {
+	for index, expectedValue := range expectedEntries {
+		if index < 0 {
+			addWindow(arrayWindowEntry{key: ArrayRange{From: index, To: index}},
+				index, expectedValue)
+			continue
+		}
 		if index > maxIndex {
 			maxIndex = index
 		}
+		indexes[index] = expectedValue
 	}
 
-	if a.expectedModel.Kind() == reflect.Array {
-		maxLength = a.expectedModel.Len()
+	if len(expectedRanges) > 0 {
+		for r, expectedValue := range expectedRanges[0] {
+			if (r.From < 0) == (r.To < 0) && r.From > r.To {
+				panic(fmt.Sprintf("bad ArrayRange %s, From > To", r))
+			}
+			addWindow(arrayWindowEntry{key: r, isRange: true}, r, expectedValue)
+		}
+	}
+
+	sort.Slice(a.windowEntries, func(i, j int) bool {
+		return a.windowEntries[i].key.less(a.windowEntries[j].key)
+	})
 
+	if maxLength >= 0 {
 		if maxLength <= maxIndex {
 			panic(fmt.Sprintf(
 				"array length is %d, so cannot have #%d expected index",
@@ -118,8 +303,6 @@ func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries) {
 		}
 		numEntries = maxLength
 	} else {
-		maxLength = -1
-
 		numEntries = maxIndex + 1
 		if numEntries < a.expectedModel.Len() {
 			numEntries = a.expectedModel.Len()
@@ -127,50 +310,25 @@ func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries) {
 	}
 
 	a.expectedEntries = make([]reflect.Value, numEntries)
+	a.implicitEntries = make([]bool, numEntries)
 
-	elemType := a.expectedModel.Type().Elem()
-	var vexpectedValue reflect.Value
-	for index, expectedValue := range expectedEntries {
-		if expectedValue == nil {
-			switch elemType.Kind() {
-			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
-				reflect.Ptr, reflect.Slice:
-				vexpectedValue = reflect.Zero(elemType) // change to a typed nil
-			default:
-				panic(fmt.Sprintf(
-					"expected value of #%d cannot be nil as items type is %s",
-					index,
-					elemType))
-			}
-		} else {
-			vexpectedValue = reflect.ValueOf(expectedValue)
-
-			if _, ok := expectedValue.(TestDeep); !ok {
-				if !vexpectedValue.Type().AssignableTo(elemType) {
-					panic(fmt.Sprintf(
-						"type %s of #%d expected value differs from %s contents (%s)",
-						vexpectedValue.Type(),
-						index,
-						ternStr(maxLength < 0, "slice", "array"),
-						elemType))
-				}
-			}
-		}
-
-		a.expectedEntries[index] = vexpectedValue
+	for index, expectedValue := range indexes {
+		a.expectedEntries[index] = a.expectedValue(index, expectedValue)
 	}
 
 	// Check initialized entries in model
-	vzero := reflect.Zero(elemType)
+	vzero := getTypeInfo(a.expectedModel.Type().Elem()).zero
 	zero := vzero.Interface()
 	for index := a.expectedModel.Len() - 1; index >= 0; index-- {
 		ventry := a.expectedModel.Index(index)
 
+		isZero := reflect.DeepEqual(zero, ventry.Interface())
+
 		// Entry already expected
-		if _, ok := expectedEntries[index]; ok {
+		if _, ok := indexes[index]; ok {
 			// If non-zero entry, consider it as an error (= 2 expected
 			// values for the same item)
-			if !reflect.DeepEqual(zero, ventry.Interface()) {
+			if !isZero {
 				panic(fmt.Sprintf(
 					"non zero #%d entry in model already exists in expectedEntries",
 					index))
@@ -179,6 +337,7 @@ func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries) {
 		}
 
 		a.expectedEntries[index] = ventry
+		a.implicitEntries[index] = isZero
 	}
 
 	// Array case, all is OK
@@ -188,8 +347,9 @@ func (a *tdArray) populateExpectedEntries(expectedEntries ArrayEntries) {
 
 	// Slice case, initialize missing expected items to zero
 	for index := a.expectedModel.Len(); index < numEntries; index++ {
-		if _, ok := expectedEntries[index]; !ok {
+		if _, ok := indexes[index]; !ok {
 			a.expectedEntries[index] = vzero
+			a.implicitEntries[index] = true
 		}
 	}
 }
@@ -230,7 +390,30 @@ func (a *tdArray) Match(ctx Context, got reflect.Value) (err *Error) {
 	}
 
 	gotLen := got.Len()
-	for index, expectedValue := range a.expectedEntries {
+
+	// Resolve window entries against got length
+	var windows []ArrayRange
+	if len(a.windowEntries) > 0 {
+		windows = make([]ArrayRange, len(a.windowEntries))
+		for i, window := range a.windowEntries {
+			from, to, ok, badBound := window.key.resolve(gotLen)
+			if !ok {
+				if ctx.booleanError {
+					return booleanError
+				}
+				return &Error{
+					Context:  ctx.AddArrayIndex(badBound),
+					Message:  "expected value out of range",
+					Got:      rawString("<non-existent value>"),
+					Expected: window.expected,
+					Location: a.GetLocation(),
+				}
+			}
+			windows[i] = ArrayRange{From: from, To: to}
+		}
+	}
+
+	for index := 0; index < gotLen || index < len(a.expectedEntries); index++ {
 		curCtx := ctx.AddArrayIndex(index)
 
 		if index >= gotLen {
@@ -241,27 +424,46 @@ func (a *tdArray) Match(ctx Context, got reflect.Value) (err *Error) {
 				Context:  curCtx,
 				Message:  "expected value out of range",
 				Got:      rawString("<non-existent value>"),
-				Expected: expectedValue,
+				Expected: a.expectedEntries[index],
 				Location: a.GetLocation(),
 			}
 		}
 
-		err = deepValueEqual(curCtx, got.Index(index), expectedValue)
-		if err != nil {
-			return err.SetLocationIfMissing(a)
+		inWindow := false
+		for _, window := range windows {
+			if index >= window.From && index <= window.To {
+				inWindow = true
+				break
+			}
 		}
-	}
 
-	if gotLen > len(a.expectedEntries) {
-		if ctx.booleanError {
-			return booleanError
+		if index < len(a.expectedEntries) {
+			if !inWindow || !a.implicitEntries[index] {
+				err = deepValueEqual(curCtx, got.Index(index), a.expectedEntries[index])
+				if err != nil {
+					return err.SetLocationIfMissing(a)
+				}
+			}
+		} else if !inWindow && !a.isSuper {
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  curCtx,
+				Message:  "got value out of range",
+				Got:      got.Index(index),
+				Expected: rawString("<non-existent value>"),
+				Location: a.GetLocation(),
+			}
 		}
-		return &Error{
-			Context:  ctx.AddArrayIndex(len(a.expectedEntries)),
-			Message:  "got value out of range",
-			Got:      got.Index(len(a.expectedEntries)),
-			Expected: rawString("<non-existent value>"),
-			Location: a.GetLocation(),
+
+		for i, window := range windows {
+			if index >= window.From && index <= window.To {
+				err = deepValueEqual(curCtx, got.Index(index), a.windowEntries[i].expected)
+				if err != nil {
+					return err.SetLocationIfMissing(a)
+				}
+			}
 		}
 	}
 
@@ -269,19 +471,31 @@ func (a *tdArray) Match(ctx Context, got reflect.Value) (err *Error) {
 }
 
 func (a *tdArray) String() string {
-	buf := bytes.NewBufferString(ternStr(a.expectedModel.Kind() == reflect.Array,
-		"Array(", "Slice("))
+	var prefix string
+	switch {
+	case a.expectedModel.Kind() == reflect.Array:
+		prefix = "Array("
+	case a.isSuper:
+		prefix = "SuperSliceOf("
+	default:
+		prefix = "Slice("
+	}
+	buf := bytes.NewBufferString(prefix)
 
 	buf.WriteString(a.expectedTypeStr())
 
-	if len(a.expectedEntries) == 0 {
+	if len(a.expectedEntries) == 0 && len(a.windowEntries) == 0 {
 		buf.WriteString("{})")
 	} else {
 		buf.WriteString("{\n")
 
 		for index, expectedValue := range a.expectedEntries {
-			fmt.Fprintf(buf, "  %d: %s\n", // nolint: errcheck
-				index, toString(expectedValue))
+			fmt.Fprintf(buf, "  %d: ", index) // nolint: errcheck
+			valueToBuffer(buf, expectedValue, "  ").WriteByte('\n')
+		}
+		for _, window := range a.windowEntries {
+			fmt.Fprintf(buf, "  %s: ", window.keyString()) // nolint: errcheck
+			valueToBuffer(buf, window.expected, "  ").WriteByte('\n')
 		}
 
 		buf.WriteString("})")
@@ -289,6 +503,32 @@ func (a *tdArray) String() string {
 	return buf.String()
 }
 
+func (a *tdArray) treeChildren() []treeChild {
+	children := make([]treeChild, 0, len(a.expectedEntries)+len(a.windowEntries))
+	for index, expectedValue := range a.expectedEntries {
+		children = append(children, treeChild{
+			name:  fmt.Sprintf("[%d]", index),
+			value: expectedValue,
+		})
+	}
+	for _, window := range a.windowEntries {
+		children = append(children, treeChild{
+			name:  "[" + window.keyString() + "]",
+			value: window.expected,
+		})
+	}
+	return children
+}
+
+// keyString returns the ArrayEntries or ArrayRanges key of w as a
+// string.
+func (w arrayWindowEntry) keyString() string {
+	if w.isRange {
+		return w.key.String()
+	}
+	return fmt.Sprint(w.key.From)
+}
+
 func (s *tdArray) TypeBehind() reflect.Type {
 	if s.isPtr {
 		return reflect.New(s.expectedModel.Type()).Type()
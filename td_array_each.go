@@ -30,63 +30,27 @@ func ArrayEach(expectedValue interface{}) TestDeep {
 }
 
 func (a *tdArrayEach) Match(ctx Context, got reflect.Value) (err *Error) {
-	if !got.IsValid() {
-		if ctx.booleanError {
-			return booleanError
-		}
-		return &Error{
-			Context:  ctx,
-			Message:  "nil value",
-			Got:      rawString("nil"),
-			Expected: rawString("Slice OR Array OR *Slice OR *Array"),
-			Location: a.GetLocation(),
-		}
+	got, err = getArrayOrSlice(ctx, got, a)
+	if err != nil {
+		return err
 	}
 
-	switch got.Kind() {
-	case reflect.Ptr:
-		gotElem := got.Elem()
-		if !gotElem.IsValid() {
-			if ctx.booleanError {
-				return booleanError
-			}
-			return &Error{
-				Context:  ctx,
-				Message:  "nil pointer",
-				Got:      rawString("nil " + got.Type().String()),
-				Expected: rawString("Slice OR Array OR *Slice OR *Array"),
-				Location: a.GetLocation(),
-			}
-		}
-
-		if gotElem.Kind() != reflect.Array && gotElem.Kind() != reflect.Slice {
-			break
-		}
-		got = gotElem
-		fallthrough
-
-	case reflect.Array, reflect.Slice:
-		gotLen := got.Len()
-
-		for idx := 0; idx < gotLen; idx++ {
-			err = deepValueEqual(ctx.AddArrayIndex(idx), got.Index(idx), a.expected)
-			if err != nil {
-				return err.SetLocationIfMissing(a)
-			}
+	gotLen := got.Len()
+	for idx := 0; idx < gotLen; idx++ {
+		err = deepValueEqual(ctx.AddArrayIndex(idx), got.Index(idx), a.expected)
+		if err != nil {
+			return err.SetLocationIfMissing(a)
 		}
-		return nil
 	}
+	return nil
+}
 
-	if ctx.booleanError {
-		return booleanError
-	}
-	return &Error{
-		Context:  ctx,
-		Message:  "bad type",
-		Got:      rawString(got.Type().String()),
-		Expected: rawString("Slice OR Array OR *Slice OR *Array"),
-		Location: a.GetLocation(),
-	}
+// getArrayOrSlice returns the array or slice behind "got", following
+// a pointer if needed. If "got" is neither an array nor a slice nor a
+// pointer on one of them, an *Error located at "td" is returned.
+func getArrayOrSlice(ctx Context, got reflect.Value, td TestDeep) (reflect.Value, *Error) {
+	return getKindValue(ctx, got, td,
+		"Slice OR Array OR *Slice OR *Array", reflect.Slice, reflect.Array)
 }
 
 func (a *tdArrayEach) String() string {
@@ -98,3 +62,7 @@ func (a *tdArrayEach) String() string {
 	}
 	return prefix + content + ")"
 }
+
+func (a *tdArrayEach) treeChildren() []treeChild {
+	return []treeChild{{value: a.expected}}
+}
@@ -344,3 +344,155 @@ func TestSliceTypeBehind(t *testing.T) {
 	equalTypes(t, Slice(MySlice{}, nil), MySlice{})
 	equalTypes(t, Slice(&MySlice{}, nil), &MySlice{})
 }
+
+func TestArrayEntriesWindows(t *testing.T) {
+	//
+	// Negative indexes
+	checkOK(t, []int{1, 2, 3}, Slice([]int{1, 2}, ArrayEntries{-1: 3}))
+	checkOK(t, []int{1, 2, 3}, Slice([]int{}, ArrayEntries{0: 1, 1: 2, -1: 3}))
+	checkOK(t, []int{3}, Slice([]int{}, ArrayEntries{0: 3, -1: 3}))
+	checkOK(t, [3]int{1, 2, 3}, Array([3]int{1, 2}, ArrayEntries{-1: 3}))
+
+	checkError(t, []int{1, 2, 3}, Slice([]int{1, 2}, ArrayEntries{-1: 4}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[2]"),
+			Got:      mustBe("(int) 3"),
+			Expected: mustBe("(int) 4"),
+		})
+	checkError(t, []int{1}, Slice([]int{}, ArrayEntries{-2: Ignore()}),
+		expectedError{
+			Message:  mustBe("expected value out of range"),
+			Path:     mustBe("DATA[-2]"),
+			Got:      mustBe("<non-existent value>"),
+			Expected: mustBe("Ignore()"),
+		})
+	// An explicitly expected item has to match all its expectations
+	checkError(t, []int{5}, Slice([]int{1}, ArrayEntries{-1: 5}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("(int) 5"),
+			Expected: mustBe("(int) 1"),
+		})
+	// Items between the model and the negative index are not expected
+	checkError(t, []int{1, 7, 5}, Slice([]int{1}, ArrayEntries{-1: 5}),
+		expectedError{
+			Message:  mustBe("got value out of range"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("(int) 7"),
+			Expected: mustBe("<non-existent value>"),
+		})
+
+	//
+	// Ranges
+	checkOK(t, []int{1, 7, 8, 5},
+		Slice([]int{1}, ArrayEntries{-1: 5}, ArrayRanges{ArrayRange{1, -2}: Gt(5)}))
+	checkOK(t, []int{1, 5},
+		Slice([]int{1}, ArrayEntries{-1: 5}, ArrayRanges{ArrayRange{1, -2}: Gt(5)}))
+	checkOK(t, []int{1, 2, 3}, Slice([]int{}, nil, ArrayRanges{ArrayRange{0, -1}: Gt(0)}))
+	checkOK(t, []int{}, Slice([]int{}, nil, ArrayRanges{ArrayRange{0, -1}: Gt(0)}))
+	// Implicit zero items are overridden by ranges
+	checkOK(t, []int{1, 2, 3}, Slice([]int{0, 2}, nil, ArrayRanges{ArrayRange{0, -1}: Gt(0)}))
+	checkOK(t, [4]int{1, 2, 3, 4},
+		Array([4]int{}, nil, ArrayRanges{ArrayRange{0, 1}: Lt(3), ArrayRange{-2, -1}: Gt(2)}))
+
+	checkError(t, []int{1, 7, 3, 5},
+		Slice([]int{1}, ArrayEntries{-1: 5}, ArrayRanges{ArrayRange{1, -2}: Gt(5)}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[2]"),
+			Got:      mustBe("3"),
+			Expected: mustBe("> 5"),
+		})
+	checkError(t, []int{1, 2}, Slice([]int{}, nil, ArrayRanges{ArrayRange{0, 2}: Ignore()}),
+		expectedError{
+			Message:  mustBe("expected value out of range"),
+			Path:     mustBe("DATA[2]"),
+			Got:      mustBe("<non-existent value>"),
+			Expected: mustBe("Ignore()"),
+		})
+	checkError(t, []int{1, 2}, Slice([]int{}, nil, ArrayRanges{ArrayRange{-3, -1}: Ignore()}),
+		expectedError{
+			Message:  mustBe("expected value out of range"),
+			Path:     mustBe("DATA[-3]"),
+			Got:      mustBe("<non-existent value>"),
+			Expected: mustBe("Ignore()"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Array([2]int{}, ArrayEntries{-3: 1}) },
+		"array length is 2, so cannot have #-3 expected index")
+	checkPanic(t, func() { Array([2]int{}, nil, ArrayRanges{ArrayRange{0, 2}: 1}) },
+		"array length is 2, so cannot have #2 expected index")
+	checkPanic(t, func() { Slice([]int{}, nil, ArrayRanges{ArrayRange{2, 1}: 1}) },
+		"bad ArrayRange 2..1, From > To")
+	checkPanic(t, func() { Slice([]int{}, nil, ArrayRanges{}, ArrayRanges{}) },
+		"usage: Slice(SLICE|&SLICE, EXPECTED_ENTRIES[, EXPECTED_RANGES])")
+	checkPanic(t, func() { Array([2]int{}, nil, ArrayRanges{}, ArrayRanges{}) },
+		"usage: Array(ARRAY|&ARRAY, EXPECTED_ENTRIES[, EXPECTED_RANGES])")
+	checkPanic(t, func() { Slice([]int{}, ArrayEntries{-1: "bad"}) },
+		"type string of #-1 expected value differs from slice contents (int)")
+	checkPanic(t, func() { Slice([]int{}, nil, ArrayRanges{ArrayRange{0, -1}: nil}) },
+		"expected value of #0..-1 cannot be nil as items type is int")
+
+	//
+	// String
+	equalStr(t, Slice([]int{1}, ArrayEntries{-1: 5}, ArrayRanges{ArrayRange{1, -2}: Gt(5)}).String(),
+		`Slice([]int{
+  0: (int) 1
+  1..-2: > 5
+  -1: (int) 5
+})`)
+}
+
+func TestSuperSliceOf(t *testing.T) {
+	type MySlice []int
+
+	checkOK(t, []int{}, SuperSliceOf([]int{}, nil))
+	checkOK(t, []int{1, 2, 3}, SuperSliceOf([]int{}, nil))
+	checkOK(t, []int{1, 2, 3}, SuperSliceOf([]int{1, 2}, nil))
+	checkOK(t, []int{1, 2, 3}, SuperSliceOf([]int{1}, ArrayEntries{1: 2}))
+	checkOK(t, &MySlice{1, 2, 3, 4, 5},
+		SuperSliceOf(&MySlice{1, 2}, ArrayEntries{-1: 5}))
+
+	checkError(t, []int{1, 2, 3}, SuperSliceOf([]int{1, 3}, nil),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("(int) 2"),
+			Expected: mustBe("(int) 3"),
+		})
+	checkError(t, []int{1}, SuperSliceOf([]int{1, 2}, nil),
+		expectedError{
+			Message:  mustBe("expected value out of range"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("<non-existent value>"),
+			Expected: mustBe("(int) 2"),
+		})
+	checkError(t, MySlice{1}, SuperSliceOf([]int{1}, nil),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("testdeep_test.MySlice"),
+			Expected: mustBe("[]int"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { SuperSliceOf("test", nil) }, "usage: SuperSliceOf(")
+	checkPanic(t, func() { SuperSliceOf([0]int{}, nil) }, "usage: SuperSliceOf(")
+
+	//
+	// String
+	equalStr(t, SuperSliceOf(MySlice{1}, nil).String(),
+		`SuperSliceOf(testdeep_test.MySlice{
+  0: (int) 1
+})`)
+
+	//
+	// TypeBehind
+	equalTypes(t, SuperSliceOf([]int{}, nil), []int{})
+	equalTypes(t, SuperSliceOf(&MySlice{}, nil), &MySlice{})
+}
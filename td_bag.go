@@ -24,6 +24,10 @@ var _ TestDeep = &tdBag{}
 //   CmpDeeply(t, []int{1, 1, 2}, Bag(2, 1, 1))    // succeeds
 //   CmpDeeply(t, []int{1, 1, 2}, Bag(1, 2))       // fails, one 1 is missing
 //   CmpDeeply(t, []int{1, 1, 2}, Bag(1, 2, 1, 3)) // fails, 3 is missing
+//
+// An item expected several times can be wrapped in Times operator:
+//
+//   CmpDeeply(t, []int{1, 1, 2}, Bag(Times(1, 2), 2)) // succeeds
 func Bag(expectedItems ...interface{}) TestDeep {
 	bag := &tdBag{
 		tdSetBase: newSetBase(allSet, false),
@@ -0,0 +1,280 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type tdBagBy struct {
+	BaseOKNil
+	key        itemKey
+	ignoreDups bool
+
+	expectedItems []reflect.Value
+	expectedKeys  []reflect.Value
+	// indexes of expected items sharing the same key, in the order of
+	// their first appearance
+	groups [][]int
+}
+
+var _ TestDeep = &tdBagBy{}
+
+// BagBy operator compares the contents of an array or a slice (or a
+// pointer on array/slice) without taking care of the order of items,
+// as Bag does. But instead of being compared as a whole, items are
+// first paired by key, then each got item is compared to the expected
+// item sharing its key.
+//
+// If "by" is a string, it is the name of the struct field (of items
+// or of the structs they point to) used as key. If "by" is a func(T) K
+// function, it returns the key of each item. Expected items cannot be
+// TestDeep operators, as their key has to be computed.
+//
+//   CmpDeeply(t, records, BagBy("ID",
+//     Record{ID: 1, Name: "Alice"},
+//     Record{ID: 2, Name: "Bob"}))
+//
+// During a match, each expected key has to be found in compared
+// array/slice, and each array/slice item key has to be expected to
+// succeed. When several items share the same key, they are paired so
+// that each got item matches its expected item, whatever their
+// order. In case of failure, the items sharing a key but differing
+// are reported, as well as the missing and extra keys.
+func BagBy(by interface{}, expectedItems ...interface{}) TestDeep {
+	return newBagBy(by, expectedItems, false)
+}
+
+// SetBy operator compares the contents of an array or a slice (or a
+// pointer on array/slice) without taking care of the order of items,
+// as BagBy does, but ignoring duplicate keys: each got item is
+// compared to the expected item sharing its key, whatever the number
+// of got items sharing this key. Several expected items can share the
+// same key only if they are equal, SetBy panics otherwise.
+//
+// See BagBy for details.
+func SetBy(by interface{}, expectedItems ...interface{}) TestDeep {
+	return newBagBy(by, expectedItems, true)
+}
+
+func newBagBy(by interface{}, expectedItems []interface{}, ignoreDups bool) *tdBagBy {
+	b := tdBagBy{
+		BaseOKNil:  NewBaseOKNil(4),
+		ignoreDups: ignoreDups,
+	}
+
+	funcName := b.GetLocation().Func
+	usage := "usage: " + funcName + "(FIELD|KEY_FUNC, EXPECTED_ITEMS...)"
+
+	if by == nil {
+		panic(usage)
+	}
+	b.key = newItemKey(by, funcName, usage)
+
+	b.expectedItems = make([]reflect.Value, len(expectedItems))
+	b.expectedKeys = make([]reflect.Value, len(expectedItems))
+
+	ctx := NewContext("EXPECTED_ITEMS")
+	for idx, item := range expectedItems {
+		switch item.(type) {
+		case nil:
+			panic(fmt.Sprintf("%s: expected item #%d cannot be nil", funcName, idx))
+		case TestDeep:
+			panic(fmt.Sprintf(
+				"%s: expected item #%d cannot be a TestDeep operator, as its key has to be computed",
+				funcName, idx))
+		}
+
+		vitem := reflect.ValueOf(item)
+		key, err := b.key.get(ctx.AddArrayIndex(idx), vitem, &b)
+		if err != nil {
+			panic(fmt.Sprintf("%s: cannot get key of expected item: %s: %s",
+				funcName, err.Context.Path(), err.Message))
+		}
+
+		b.expectedItems[idx] = vitem
+		b.expectedKeys[idx] = key
+
+		groupIdx := b.findGroup(NewBooleanContext(), key)
+		if groupIdx < 0 {
+			b.groups = append(b.groups, []int{idx})
+			continue
+		}
+
+		if ignoreDups {
+			firstIdx := b.groups[groupIdx][0]
+			if deepValueEqual(NewBooleanContext(),
+				vitem, b.expectedItems[firstIdx]) != nil {
+				panic(fmt.Sprintf(
+					"%s: expected items #%d and #%d share the same key but differ",
+					funcName, firstIdx, idx))
+			}
+		}
+		b.groups[groupIdx] = append(b.groups[groupIdx], idx)
+	}
+
+	return &b
+}
+
+// findGroup returns the index of the group of expected items whose
+// key is "key", or -1 if not found.
+func (b *tdBagBy) findGroup(ctx Context, key reflect.Value) int {
+	for groupIdx, group := range b.groups {
+		if deepValueEqual(ctx, key, b.expectedKeys[group[0]]) == nil {
+			return groupIdx
+		}
+	}
+	return -1
+}
+
+func (b *tdBagBy) Match(ctx Context, got reflect.Value) (err *Error) {
+	got, err = getArrayOrSlice(ctx, got, b)
+	if err != nil {
+		return err
+	}
+
+	gotLen := got.Len()
+	gotKeys := make([]reflect.Value, gotLen)
+	gotGroups := make([][]int, len(b.groups))
+	var extra []int
+	for idx := range gotKeys {
+		gotKeys[idx], err = b.key.get(ctx.AddArrayIndex(idx), got.Index(idx), b)
+		if err != nil {
+			return err
+		}
+
+		groupIdx := b.findGroup(ctx.boolean(), gotKeys[idx])
+		if groupIdx < 0 {
+			if ctx.booleanError {
+				return booleanError
+			}
+			extra = append(extra, idx)
+			continue
+		}
+		gotGroups[groupIdx] = append(gotGroups[groupIdx], idx)
+	}
+
+	res := tdSetResult{
+		Kind: keysSetResult,
+	}
+
+	addDiff := func(gotIdx, expectedIdx int) *Error {
+		err := deepValueEqual(ctx.AddArrayIndex(gotIdx),
+			got.Index(gotIdx), b.expectedItems[expectedIdx])
+		if err != nil {
+			if ctx.booleanError {
+				return booleanError
+			}
+			res.Differing = append(res.Differing, tdSetDiff{
+				Key: gotKeys[gotIdx],
+				Err: err,
+			})
+		}
+		return nil
+	}
+
+	for groupIdx, expectedIdxes := range b.groups {
+		gotIdxes := gotGroups[groupIdx]
+
+		// SetBy: each got item is compared to the first expected item,
+		// the others being equal to it
+		if b.ignoreDups {
+			if len(gotIdxes) == 0 {
+				if ctx.booleanError {
+					return booleanError
+				}
+				res.Missing = append(res.Missing, b.expectedKeys[expectedIdxes[0]])
+				continue
+			}
+			for _, gotIdx := range gotIdxes {
+				if err = addDiff(gotIdx, expectedIdxes[0]); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		// BagBy: pair expected and got items sharing this key, whatever
+		// their order
+		m := newUnorderedMatcher(len(expectedIdxes), len(gotIdxes),
+			func(e, g int) bool {
+				return deepValueEqual(ctx.boolean(),
+					got.Index(gotIdxes[g]), b.expectedItems[expectedIdxes[e]]) == nil
+			})
+
+		var unpairedExpected []int
+		for e := range expectedIdxes {
+			if !m.pair(e) {
+				if ctx.booleanError {
+					return booleanError
+				}
+				unpairedExpected = append(unpairedExpected, expectedIdxes[e])
+			}
+		}
+
+		var unpairedGot []int
+		for g, e := range m.gotPair {
+			if e < 0 {
+				if ctx.booleanError {
+					return booleanError
+				}
+				unpairedGot = append(unpairedGot, gotIdxes[g])
+			}
+		}
+
+		// Remaining items are compared in order, then reported as
+		// missing or extra
+		for len(unpairedExpected) > 0 && len(unpairedGot) > 0 {
+			if err = addDiff(unpairedGot[0], unpairedExpected[0]); err != nil {
+				return err
+			}
+			unpairedGot, unpairedExpected = unpairedGot[1:], unpairedExpected[1:]
+		}
+		for _, expectedIdx := range unpairedExpected {
+			res.Missing = append(res.Missing, b.expectedKeys[expectedIdx])
+		}
+		extra = append(extra, unpairedGot...)
+	}
+
+	if res.IsEmpty() && len(extra) == 0 {
+		return nil
+	}
+
+	sort.Ints(extra)
+	for _, gotIdx := range extra {
+		res.Extra = append(res.Extra, gotKeys[gotIdx])
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "comparing %% as a " + b.GetLocation().Func,
+		Summary:  res,
+		Location: b.GetLocation(),
+	}
+}
+
+func (b *tdBagBy) String() string {
+	return b.stringToBuffer(&bytes.Buffer{}).String()
+}
+
+func (b *tdBagBy) stringToBuffer(buf *bytes.Buffer) *bytes.Buffer {
+	items := make([]reflect.Value, 0, len(b.expectedItems)+1)
+	items = append(items, reflect.ValueOf(rawString(b.key.String())))
+	items = append(items, b.expectedItems...)
+	buf.WriteString(b.GetLocation().Func)
+	return sliceToBuffer(buf, items)
+}
+
+func (b *tdBagBy) treeChildren() []treeChild {
+	children := make([]treeChild, len(b.expectedItems))
+	for idx, item := range b.expectedItems {
+		children[idx].value = item
+	}
+	return children
+}
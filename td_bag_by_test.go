@@ -0,0 +1,195 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+type bagByRecord struct {
+	ID   int
+	Name string
+}
+
+func TestBagBy(t *testing.T) {
+	got := []bagByRecord{
+		{ID: 3, Name: "Charlie"},
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+	}
+
+	checkOK(t, got, BagBy("ID",
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 2, Name: "Bob"},
+		bagByRecord{ID: 3, Name: "Charlie"}))
+	checkOK(t, &got, BagBy("Name",
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 2, Name: "Bob"},
+		bagByRecord{ID: 3, Name: "Charlie"}))
+	checkOK(t, got, BagBy(func(r bagByRecord) int { return r.ID },
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 2, Name: "Bob"},
+		bagByRecord{ID: 3, Name: "Charlie"}))
+	checkOK(t, []*bagByRecord{&got[0], &got[1]}, BagBy("ID",
+		&bagByRecord{ID: 1, Name: "Alice"},
+		&bagByRecord{ID: 3, Name: "Charlie"}))
+	checkOK(t, []bagByRecord{}, BagBy("ID"))
+
+	checkError(t, got,
+		BagBy("ID",
+			bagByRecord{ID: 1, Name: "Alice"},
+			bagByRecord{ID: 2, Name: "Bobby"},
+			bagByRecord{ID: 4, Name: "David"}),
+		expectedError{
+			Message: mustBe("comparing %% as a BagBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing keys: ((int) 4)
+  Extra keys: ((int) 3)
+  Differing keys: (int) 2 → DATA[2].Name: values differ (got: (string) (len=3) "Bob", expected: (string) (len=5) "Bobby")`),
+		})
+
+	checkError(t, got,
+		BagBy("ID",
+			bagByRecord{ID: 1, Name: "Alicia"},
+			bagByRecord{ID: 2, Name: "Bobby"},
+			bagByRecord{ID: 3, Name: "Charlie"}),
+		expectedError{
+			Message: mustBe("comparing %% as a BagBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Differing keys: (int) 1 → DATA[1].Name: values differ (got: (string) (len=5) "Alice", expected: (string) (len=6) "Alicia")
+                (int) 2 → DATA[2].Name: values differ (got: (string) (len=3) "Bob", expected: (string) (len=5) "Bobby")`),
+		})
+
+	// Duplicate keys
+	dups := []bagByRecord{{ID: 1, Name: "Alice"}, {ID: 1, Name: "Alice"}}
+	checkOK(t, dups, BagBy("ID",
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 1, Name: "Alice"}))
+	checkError(t, dups, BagBy("ID", bagByRecord{ID: 1, Name: "Alice"}),
+		expectedError{
+			Message: mustBe("comparing %% as a BagBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Extra keys: ((int) 1)"),
+		})
+
+	// Items sharing a key are paired whatever their order
+	dups = []bagByRecord{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Charlie"}, {ID: 1, Name: "Alice"}}
+	checkOK(t, dups, BagBy("ID",
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 2, Name: "Charlie"},
+		bagByRecord{ID: 1, Name: "Bob"}))
+	checkError(t, dups,
+		BagBy("ID",
+			bagByRecord{ID: 1, Name: "Alice"},
+			bagByRecord{ID: 2, Name: "Charlie"},
+			bagByRecord{ID: 1, Name: "Bobby"}),
+		expectedError{
+			Message: mustBe("comparing %% as a BagBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Differing keys: (int) 1 → DATA[0].Name: values differ (got: (string) (len=3) "Bob", expected: (string) (len=5) "Bobby")`),
+		})
+	checkError(t, dups,
+		BagBy("ID",
+			bagByRecord{ID: 1, Name: "Bob"},
+			bagByRecord{ID: 2, Name: "Charlie"},
+			bagByRecord{ID: 1, Name: "Alice"},
+			bagByRecord{ID: 1, Name: "Alice"}),
+		expectedError{
+			Message: mustBe("comparing %% as a BagBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing keys: ((int) 1)"),
+		})
+
+	//
+	// Bad got
+	checkError(t, []int{1}, BagBy("ID", bagByRecord{ID: 1}),
+		expectedError{
+			Message:  mustBe("field not found"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("int"),
+			Expected: mustBe("struct with field ID"),
+		})
+	checkError(t, 12, BagBy("ID", bagByRecord{ID: 1}),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { BagBy(nil) }, "usage: BagBy(")
+	checkPanic(t, func() { BagBy(12) }, "usage: BagBy(")
+	checkPanic(t, func() { BagBy("") }, "usage: BagBy(")
+	checkPanic(t, func() { BagBy(func() int { return 0 }) },
+		"BagBy(KEY_FUNC): KEY_FUNC must take one argument and return one value")
+	checkPanic(t, func() { BagBy("ID", nil) },
+		"BagBy: expected item #0 cannot be nil")
+	checkPanic(t, func() { BagBy("ID", Ignore()) },
+		"BagBy: expected item #0 cannot be a TestDeep operator")
+	checkPanic(t, func() { BagBy("ID", 12) },
+		"BagBy: cannot get key of expected item: EXPECTED_ITEMS[0]: field not found")
+
+	//
+	// String
+	equalStr(t, BagBy("ID", bagByRecord{ID: 1}).String(),
+		`BagBy("ID",
+      (testdeep_test.bagByRecord) {
+       ID: (int) 1,
+       Name: (string) ""
+      })`)
+	equalStr(t, SetBy(func(r bagByRecord) int { return r.ID }).String(),
+		"SetBy(func(testdeep_test.bagByRecord) int)")
+}
+
+func TestSetBy(t *testing.T) {
+	got := []bagByRecord{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 1, Name: "Alice"},
+	}
+
+	checkOK(t, got, SetBy("ID",
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 2, Name: "Bob"}))
+	checkOK(t, got, SetBy("ID",
+		bagByRecord{ID: 2, Name: "Bob"},
+		bagByRecord{ID: 1, Name: "Alice"},
+		bagByRecord{ID: 1, Name: "Alice"}))
+
+	checkError(t, got,
+		SetBy("ID",
+			bagByRecord{ID: 1, Name: "Alicia"},
+			bagByRecord{ID: 3, Name: "Charlie"}),
+		expectedError{
+			Message: mustBe("comparing %% as a SetBy"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing keys: ((int) 3)
+  Extra keys: ((int) 2)
+  Differing keys: (int) 1 → DATA[0].Name: values differ (got: (string) (len=5) "Alice", expected: (string) (len=6) "Alicia")
+                  (int) 1 → DATA[2].Name: values differ (got: (string) (len=5) "Alice", expected: (string) (len=6) "Alicia")`),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t,
+		func() {
+			SetBy("ID",
+				bagByRecord{ID: 1, Name: "Alice"},
+				bagByRecord{ID: 2, Name: "Bob"},
+				bagByRecord{ID: 1, Name: "Bob"})
+		},
+		"SetBy: expected items #0 and #2 share the same key but differ")
+}
+
+func TestBagByTypeBehind(t *testing.T) {
+	equalTypes(t, BagBy("ID"), nil)
+	equalTypes(t, SetBy("ID"), nil)
+}
@@ -59,7 +59,7 @@ func TestBag(t *testing.T) {
 			expectedError{
 				Message: mustBe("comparing %% as a Bag"),
 				Path:    mustBe("DATA"),
-				Summary: mustBe("Missing items: ((int) 66,\n                (int) 66)"),
+				Summary: mustBe("Missing items: ((int) 66 ×2)"),
 			},
 			testName)
 
@@ -168,6 +168,9 @@ func TestBag(t *testing.T) {
 	equalStr(t, SuperBagOf(1).String(), "SuperBagOf((int) 1)")
 	equalStr(t, SuperBagOf(1, 2).String(),
 		"SuperBagOf((int) 1,\n           (int) 2)")
+
+	equalStr(t, Bag(1, Bag(2, 3)).String(),
+		"Bag((int) 1,\n    Bag((int) 2,\n        (int) 3))")
 }
 
 func TestBagTypeBehind(t *testing.T) {
@@ -55,12 +55,23 @@ type tdBetweenTime struct {
 var _ TestDeep = &tdBetweenTime{}
 
 // Between operator checks that data is between "from" and
-// "to". "from" and "to" can be any numeric or time.Time (or
-// assignable) value. "from" and "to" must be the same kind as the
-// compared value if numeric, and the same type if time.Time (or
-// assignable). "bounds" allows to specify whether bounds are included
-// or not. See Bounds* constants for details. If "bounds" is missing,
-// it defaults to BoundsInIn.
+// "to". "from" and "to" can be any numeric, string (compared
+// lexically) or time.Time (or assignable) value, as well as any value
+// whose type has a Compare(T) int, a Cmp(T) int (as *big.Int,
+// *big.Float and *big.Rat) or a Less(T) bool method, T being the
+// type of "from". "from" and "to" must be the same type as the
+// compared value, except for predeclared integer types (resp. float
+// types) that can be compared together whatever their size or sign
+// is, as no precision is lost. An order method takes precedence over
+// the kind of the type, so a string kind type having a Compare
+// method is not compared lexically. "bounds" allows to specify whether
+// bounds are included or not. See Bounds* constants for details. If
+// "bounds" is missing, it defaults to BoundsInIn.
+//
+//   CmpDeeply(t, int64(12), Between(10, 20))                           // succeeds
+//   CmpDeeply(t, "abc", Between("abb", "abd"))                         // succeeds
+//   CmpDeeply(t, 90*time.Second, Between(time.Minute, 2*time.Minute))  // succeeds
+//   CmpDeeply(t, big.NewInt(12), Between(big.NewInt(10), big.NewInt(20))) // succeeds
 //
 // TypeBehind method returns the reflect.Type of "from" (same as the "to" one.)
 func Between(from interface{}, to interface{}, bounds ...BoundsKind) TestDeep {
@@ -69,7 +80,7 @@ func Between(from interface{}, to interface{}, bounds ...BoundsKind) TestDeep {
 		expectedMax: reflect.ValueOf(to),
 	}
 
-	const usage = "usage: Between(NUM|TIME, NUM|TIME[, BOUNDS_KIND])"
+	const usage = "usage: Between(NUM|STRING|TIME|ORDERED, NUM|STRING|TIME|ORDERED[, BOUNDS_KIND])"
 
 	if len(bounds) > 0 {
 		if len(bounds) > 1 {
@@ -106,6 +117,23 @@ func (b *tdBetween) initBetween(usage string) TestDeep {
 		b.expectedMax = b.expectedMin
 	}
 
+	// An order method takes precedence over the kind of the type
+	if b.expectedMin.IsValid() {
+		if order := orderMethodOf(b.expectedMin.Type()); order != nil {
+			cmp, ok := order(b.expectedMin, b.expectedMax)
+			if !ok {
+				panic(usage + ", bounds cannot be nil")
+			}
+			if cmp > 0 {
+				b.expectedMin, b.expectedMax = b.expectedMax, b.expectedMin
+			}
+			return &tdBetweenOrdered{
+				tdBetween: *b,
+				order:     order,
+			}
+		}
+	}
+
 	switch b.expectedMin.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if b.expectedMin.Int() > b.expectedMax.Int() {
@@ -125,14 +153,24 @@ func (b *tdBetween) initBetween(usage string) TestDeep {
 		}
 		return b
 
+	case reflect.String:
+		if b.expectedMin.String() > b.expectedMax.String() {
+			b.expectedMin, b.expectedMax = b.expectedMax, b.expectedMin
+		}
+		return b
+
 	case reflect.Struct:
+		if !b.expectedMin.Type().ConvertibleTo(timeType) {
+			break
+		}
+
 		var bt tdBetweenTime
 		if b.expectedMin.Type() == timeType {
 			bt = tdBetweenTime{
 				tdBetween:    *b,
 				expectedType: timeType,
 			}
-		} else if b.expectedMin.Type().ConvertibleTo(timeType) {
+		} else {
 			bt = tdBetweenTime{
 				tdBetween:    *b,
 				expectedType: b.expectedMin.Type(),
@@ -149,6 +187,7 @@ func (b *tdBetween) initBetween(usage string) TestDeep {
 
 		return &bt
 	}
+
 	panic(usage)
 }
 
@@ -260,9 +299,7 @@ func N(num interface{}, tolerance ...interface{}) TestDeep {
 }
 
 // Gt operator checks that data is greater than "val". "val" can be
-// any numeric or time.Time (or assignable) value. "val" must be the
-// same kind as the compared value if numeric, and the same type if
-// time.Time (or assignable).
+// any value accepted by Between, with the same type constraints.
 //
 // TypeBehind method returns the reflect.Type of "val".
 func Gt(val interface{}) TestDeep {
@@ -270,13 +307,12 @@ func Gt(val interface{}) TestDeep {
 		expectedMin: reflect.ValueOf(val),
 		minBound:    boundOut,
 	}
-	return b.initBetween("usage: Gt(NUM|TIME)")
+	return b.initBetween("usage: Gt(NUM|STRING|TIME|ORDERED)")
 }
 
 // Gte operator checks that data is greater or equal than "val". "val"
-// can be any numeric or time.Time (or assignable) value. "val" must
-// be the same kind as the compared value if numeric, and the same
-// type if time.Time (or assignable).
+// can be any value accepted by Between, with the same type
+// constraints.
 //
 // TypeBehind method returns the reflect.Type of "val".
 func Gte(val interface{}) TestDeep {
@@ -284,13 +320,11 @@ func Gte(val interface{}) TestDeep {
 		expectedMin: reflect.ValueOf(val),
 		minBound:    boundIn,
 	}
-	return b.initBetween("usage: Gte(NUM|TIME)")
+	return b.initBetween("usage: Gte(NUM|STRING|TIME|ORDERED)")
 }
 
 // Lt operator checks that data is lesser than "val". "val" can be
-// any numeric or time.Time (or assignable) value. "val" must be the
-// same kind as the compared value if numeric, and the same type if
-// time.Time (or assignable).
+// any value accepted by Between, with the same type constraints.
 //
 // TypeBehind method returns the reflect.Type of "val".
 func Lt(val interface{}) TestDeep {
@@ -298,13 +332,12 @@ func Lt(val interface{}) TestDeep {
 		expectedMin: reflect.ValueOf(val),
 		maxBound:    boundOut,
 	}
-	return b.initBetween("usage: Lt(NUM|TIME)")
+	return b.initBetween("usage: Lt(NUM|STRING|TIME|ORDERED)")
 }
 
 // Lte operator checks that data is lesser or equal than "val". "val"
-// can be any numeric or time.Time (or assignable) value. "val" must
-// be the same kind as the compared value if numeric, and the same
-// type if time.Time (or assignable).
+// can be any value accepted by Between, with the same type
+// constraints.
 //
 // TypeBehind method returns the reflect.Type of "val".
 func Lte(val interface{}) TestDeep {
@@ -312,7 +345,7 @@ func Lte(val interface{}) TestDeep {
 		expectedMin: reflect.ValueOf(val),
 		maxBound:    boundIn,
 	}
-	return b.initBetween("usage: Lte(NUM|TIME)")
+	return b.initBetween("usage: Lte(NUM|STRING|TIME|ORDERED)")
 }
 
 func (b *tdBetween) matchInt(got reflect.Value) (ok bool) {
@@ -381,37 +414,66 @@ func (b *tdBetween) matchFloat(got reflect.Value) (ok bool) {
 	return
 }
 
-func (b *tdBetween) Match(ctx Context, got reflect.Value) *Error {
-	if got.Type() != b.expectedMin.Type() {
-		if ctx.booleanError {
-			return booleanError
-		}
-		return &Error{
-			Context:  ctx,
-			Message:  "type mismatch",
-			Got:      rawString(got.Type().String()),
-			Expected: rawString(b.expectedMin.Type().String()),
-			Location: b.GetLocation(),
+func (b *tdBetween) matchString(got reflect.Value) (ok bool) {
+	switch b.minBound {
+	case boundIn:
+		ok = got.String() >= b.expectedMin.String()
+	case boundOut:
+		ok = got.String() > b.expectedMin.String()
+	default:
+		ok = true
+	}
+	if ok {
+		switch b.maxBound {
+		case boundIn:
+			ok = got.String() <= b.expectedMax.String()
+		case boundOut:
+			ok = got.String() < b.expectedMax.String()
+		default:
+			ok = true
 		}
 	}
+	return
+}
 
-	var ok bool
-
-	switch got.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		ok = b.matchInt(got)
-
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		ok = b.matchUint(got)
+// inBounds returns true if a value is between b bounds, "cmpMin"
+// (resp. "cmpMax") being the result of its comparison to
+// b.expectedMin (resp. b.expectedMax): -1, 0 or 1.
+func (b *tdBetween) inBounds(cmpMin, cmpMax int) bool {
+	switch b.minBound {
+	case boundIn:
+		if cmpMin < 0 {
+			return false
+		}
+	case boundOut:
+		if cmpMin <= 0 {
+			return false
+		}
+	}
 
-	case reflect.Float32, reflect.Float64:
-		ok = b.matchFloat(got)
+	switch b.maxBound {
+	case boundIn:
+		return cmpMax <= 0
+	case boundOut:
+		return cmpMax < 0
 	}
+	return true
+}
 
-	if ok {
-		return nil
+func (b *tdBetween) typeMismatch(ctx Context, got reflect.Value) *Error {
+	if ctx.booleanError {
+		return booleanError
 	}
+	return &Error{
+		Context:  ctx,
+		Message:  "type mismatch",
+		Got:      rawString(got.Type().String()),
+		Expected: rawString(b.expectedMin.Type().String()),
+		Location: b.GetLocation(),
+	}
+}
 
+func (b *tdBetween) notInBounds(ctx Context, got reflect.Value) *Error {
 	if ctx.booleanError {
 		return booleanError
 	}
@@ -424,6 +486,41 @@ func (b *tdBetween) Match(ctx Context, got reflect.Value) *Error {
 	}
 }
 
+func (b *tdBetween) Match(ctx Context, got reflect.Value) *Error {
+	var ok bool
+
+	if got.Type() != b.expectedMin.Type() {
+		// Predeclared numbers of the same family can be compared
+		// without any loss
+		family := numberFamily(got.Type())
+		if family == 0 || family != numberFamily(b.expectedMin.Type()) {
+			return b.typeMismatch(ctx, got)
+		}
+
+		ok = b.inBounds(compareNumbers(got, b.expectedMin),
+			compareNumbers(got, b.expectedMax))
+	} else {
+		switch got.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ok = b.matchInt(got)
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			ok = b.matchUint(got)
+
+		case reflect.Float32, reflect.Float64:
+			ok = b.matchFloat(got)
+
+		case reflect.String:
+			ok = b.matchString(got)
+		}
+	}
+
+	if ok {
+		return nil
+	}
+	return b.notInBounds(ctx, got)
+}
+
 func (b *tdBetween) String() string {
 	var min, max interface{}
 
@@ -520,3 +617,40 @@ func (b *tdBetweenTime) Match(ctx Context, got reflect.Value) *Error {
 func (b *tdBetweenTime) TypeBehind() reflect.Type {
 	return b.expectedType
 }
+
+type tdBetweenOrdered struct {
+	tdBetween
+	order orderFunc
+}
+
+var _ TestDeep = &tdBetweenOrdered{}
+
+func (b *tdBetweenOrdered) Match(ctx Context, got reflect.Value) *Error {
+	if got.Type() != b.expectedMin.Type() {
+		return b.typeMismatch(ctx, got)
+	}
+
+	if !ctx.userCodeAllowed() {
+		return booleanError
+	}
+
+	cmpMin, ok := b.order(got, b.expectedMin)
+	if !ok {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "nil pointer",
+			Got:      rawString("nil " + got.Type().String()),
+			Expected: rawString(b.String()),
+			Location: b.GetLocation(),
+		}
+	}
+	cmpMax, _ := b.order(got, b.expectedMax)
+
+	if b.inBounds(cmpMin, cmpMax) {
+		return nil
+	}
+	return b.notInBounds(ctx, got)
+}
@@ -8,6 +8,9 @@ package testdeep_test
 
 import (
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,10 +50,17 @@ func TestBetween(t *testing.T) {
 	})
 
 	checkError(t, 15, Between(uint(10), uint(15), BoundsOutOut), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("15"),
+		Expected: mustBe("10 < got < 15"),
+	})
+
+	checkError(t, 12.0, Between(10, 15), expectedError{
 		Message:  mustBe("type mismatch"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint"),
+		Got:      mustBe("float64"),
+		Expected: mustBe("int"),
 	})
 
 	checkOK(t, uint16(12), Between(uint16(9), uint16(13)))
@@ -67,7 +77,9 @@ func TestBetween(t *testing.T) {
 
 	//
 	// Bad usage
-	checkPanic(t, func() { Between("test", "test") }, "usage: Between(")
+	checkPanic(t, func() { Between([]int{}, []int{}) }, "usage: Between(")
+	checkPanic(t, func() { Between((*big.Int)(nil), big.NewInt(1)) },
+		"usage: Between(")
 	checkPanic(t, func() { Between(12, "test") },
 		"from and to params must have the same type")
 	checkPanic(t, func() { Between("test", 12) },
@@ -83,50 +95,50 @@ func TestN(t *testing.T) {
 	checkOK(t, uint(11), N(uint(12), uint(1)))
 	checkOK(t, uint(13), N(uint(12), uint(1)))
 	checkError(t, 10, N(uint(12), uint(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, uint8(12), N(uint8(12)))
 	checkOK(t, uint8(11), N(uint8(12), uint8(1)))
 	checkOK(t, uint8(13), N(uint8(12), uint8(1)))
 	checkError(t, 10, N(uint8(12), uint8(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint8"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, uint16(12), N(uint16(12)))
 	checkOK(t, uint16(11), N(uint16(12), uint16(1)))
 	checkOK(t, uint16(13), N(uint16(12), uint16(1)))
 	checkError(t, 10, N(uint16(12), uint16(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint16"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, uint32(12), N(uint32(12)))
 	checkOK(t, uint32(11), N(uint32(12), uint32(1)))
 	checkOK(t, uint32(13), N(uint32(12), uint32(1)))
 	checkError(t, 10, N(uint32(12), uint32(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint32"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, uint64(12), N(uint64(12)))
 	checkOK(t, uint64(11), N(uint64(12), uint64(1)))
 	checkOK(t, uint64(13), N(uint64(12), uint64(1)))
 	checkError(t, 10, N(uint64(12), uint64(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("uint64"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, uint64(math.MaxUint64), N(uint64(math.MaxUint64), uint64(2)))
@@ -169,40 +181,40 @@ func TestN(t *testing.T) {
 	checkOK(t, int8(11), N(int8(12), int8(1)))
 	checkOK(t, int8(13), N(int8(12), int8(1)))
 	checkError(t, 10, N(int8(12), int8(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("int8"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, int16(12), N(int16(12)))
 	checkOK(t, int16(11), N(int16(12), int16(1)))
 	checkOK(t, int16(13), N(int16(12), int16(1)))
 	checkError(t, 10, N(int16(12), int16(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("int16"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, int32(12), N(int32(12)))
 	checkOK(t, int32(11), N(int32(12), int32(1)))
 	checkOK(t, int32(13), N(int32(12), int32(1)))
 	checkError(t, 10, N(int32(12), int32(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("int32"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, int64(12), N(int64(12)))
 	checkOK(t, int64(11), N(int64(12), int64(1)))
 	checkOK(t, int64(13), N(int64(12), int64(1)))
 	checkError(t, 10, N(int64(12), int64(1)), expectedError{
-		Message:  mustBe("type mismatch"),
+		Message:  mustBe("values differ"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("int"),
-		Expected: mustBe("int64"),
+		Got:      mustBe("10"),
+		Expected: mustBe("11 ≤ got ≤ 13"),
 	})
 
 	checkOK(t, int64(math.MaxInt64), N(int64(math.MaxInt64), int64(2)))
@@ -239,9 +251,15 @@ func TestN(t *testing.T) {
 	checkOK(t, float32(11.9), N(float32(12), float32(0.1)))
 	checkOK(t, float32(12.1), N(float32(12), float32(0.1)))
 	checkError(t, 11.8, N(float32(12), float32(0.1)), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("11.8"),
+		Expected: mustBe("11.9 ≤ got ≤ 12.1"),
+	})
+	checkError(t, 12, N(float32(12), float32(0.1)), expectedError{
 		Message:  mustBe("type mismatch"),
 		Path:     mustBe("DATA"),
-		Got:      mustBe("float64"),
+		Got:      mustBe("int"),
 		Expected: mustBe("float32"),
 	})
 
@@ -336,10 +354,10 @@ func TestLGt(t *testing.T) {
 
 	//
 	// Bad usage
-	checkPanic(t, func() { Gt("test") }, "usage: Gt(")
-	checkPanic(t, func() { Gte("test") }, "usage: Gte(")
-	checkPanic(t, func() { Lt("test") }, "usage: Lt(")
-	checkPanic(t, func() { Lte("test") }, "usage: Lte(")
+	checkPanic(t, func() { Gt(struct{}{}) }, "usage: Gt(")
+	checkPanic(t, func() { Gte(struct{}{}) }, "usage: Gte(")
+	checkPanic(t, func() { Lt(struct{}{}) }, "usage: Lt(")
+	checkPanic(t, func() { Lte(struct{}{}) }, "usage: Lte(")
 }
 
 func TestBetweenTime(t *testing.T) {
@@ -415,6 +433,188 @@ func TestBetweenTime(t *testing.T) {
 	checkOK(t, now, Lt(now.Add(time.Second)))
 }
 
+type betweenVersion struct{ Major, Minor int }
+
+func (v betweenVersion) Compare(o betweenVersion) int {
+	if v.Major != o.Major {
+		return v.Major - o.Major
+	}
+	return v.Minor - o.Minor
+}
+
+func (v betweenVersion) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+type betweenLevel struct{ level int }
+
+func (l *betweenLevel) Less(o *betweenLevel) bool {
+	return l.level < o.level
+}
+
+func (l *betweenLevel) String() string {
+	return fmt.Sprintf("level %d", l.level)
+}
+
+// betweenSemver is string kind, but its Compare method orders
+// "1.10" after "1.9".
+type betweenSemver string
+
+func (v betweenSemver) Compare(o betweenSemver) int {
+	vp, op := strings.Split(string(v), "."), strings.Split(string(o), ".")
+	for i := 0; i < len(vp) && i < len(op); i++ {
+		vn, _ := strconv.Atoi(vp[i])
+		on, _ := strconv.Atoi(op[i])
+		if vn != on {
+			return vn - on
+		}
+	}
+	return len(vp) - len(op)
+}
+
+// betweenCounted counts the calls of its Compare method.
+type betweenCounted int
+
+var betweenCompareCalls int
+
+func (c betweenCounted) Compare(o betweenCounted) int {
+	betweenCompareCalls++
+	return int(c - o)
+}
+
+func TestBetweenOrdered(t *testing.T) {
+	//
+	// Mixed-width numbers
+	checkOK(t, int64(12), Between(10, 15))
+	checkOK(t, int8(12), Gt(uint64(11)))
+	checkOK(t, uint8(12), Lt(int64(13)))
+	checkOK(t, -1, Lt(uint(0)))
+	checkOK(t, uint64(math.MaxUint64), Gt(int64(math.MaxInt64)))
+	checkOK(t, float32(12.5), Between(12.0, 13.0))
+	checkError(t, int64(-1), Gt(uint64(0)), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("-1"),
+		Expected: mustBe("> 0"),
+	})
+	checkError(t, time.Duration(12), Between(10, 15), expectedError{
+		Message:  mustBe("type mismatch"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("time.Duration"),
+		Expected: mustBe("int"),
+	})
+
+	//
+	// Strings
+	checkOK(t, "abc", Between("abb", "abd"))
+	checkOK(t, "abc", Between("abd", "abb"))
+	checkOK(t, "abc", Gte("abc"))
+	checkOK(t, "abc", Lt("b"))
+	checkError(t, "abc", Between("abc", "abd", BoundsOutIn), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("abc"),
+		Expected: mustBe("abc < got ≤ abd"),
+	})
+
+	//
+	// time.Duration
+	checkOK(t, 90*time.Second, Between(time.Minute, 2*time.Minute))
+	checkError(t, 3*time.Minute, Lte(2*time.Minute), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("3m0s"),
+		Expected: mustBe("≤ 2m0s"),
+	})
+	checkError(t, 1500*time.Millisecond,
+		N(time.Second, 100*time.Millisecond), expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("1.5s"),
+			Expected: mustBe("900ms ≤ got ≤ 1.1s"),
+		})
+
+	//
+	// math/big
+	checkOK(t, big.NewInt(12), Between(big.NewInt(10), big.NewInt(15)))
+	checkOK(t, big.NewInt(12), Between(big.NewInt(15), big.NewInt(10)))
+	checkOK(t, big.NewFloat(1.5), Gt(big.NewFloat(1)))
+	checkOK(t, big.NewRat(1, 3), Lt(big.NewRat(1, 2)))
+	checkError(t, big.NewInt(12), Gt(big.NewInt(12)), expectedError{
+		Message:  mustBe("values differ"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("12"),
+		Expected: mustBe("> 12"),
+	})
+	checkError(t, (*big.Int)(nil), Gt(big.NewInt(12)), expectedError{
+		Message:  mustBe("nil pointer"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("nil *big.Int"),
+		Expected: mustBe("> 12"),
+	})
+	checkError(t, 12, Gt(big.NewInt(12)), expectedError{
+		Message:  mustBe("type mismatch"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("int"),
+		Expected: mustBe("*big.Int"),
+	})
+
+	//
+	// Compare & Less methods
+	checkOK(t, betweenVersion{1, 2},
+		Between(betweenVersion{1, 0}, betweenVersion{2, 0}, BoundsInOut))
+	checkError(t, betweenVersion{2, 0},
+		Between(betweenVersion{1, 0}, betweenVersion{2, 0}, BoundsInOut),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("v2.0"),
+			Expected: mustBe("v1.0 ≤ got < v2.0"),
+		})
+	// Compare method is user code, so called only during the full
+	// pass of CmpDeeply
+	gtTwo := Gt(betweenCounted(2))
+	betweenCompareCalls = 0
+	EqDeeply(betweenCounted(1), gtTwo) // single pass
+	singlePass := betweenCompareCalls
+	betweenCompareCalls = 0
+	if CmpDeeply(&testing.T{}, betweenCounted(1), gtTwo) {
+		t.Error("CmpDeeply should fail")
+	}
+	if betweenCompareCalls != singlePass {
+		t.Errorf("Compare method called %d times instead of %d",
+			betweenCompareCalls, singlePass)
+	}
+
+	// Compare method takes precedence over string kind
+	checkOK(t, betweenSemver("1.10"), Gt(betweenSemver("1.9")))
+	checkOK(t, betweenSemver("1.10"),
+		Between(betweenSemver("1.9"), betweenSemver("1.11")))
+	checkError(t, betweenSemver("1.9"), Gt(betweenSemver("1.10")),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("1.9"),
+			Expected: mustBe("> 1.10"),
+		})
+	checkError(t, "1.10", Gt(betweenSemver("1.9")),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("string"),
+			Expected: mustBe("testdeep_test.betweenSemver"),
+		})
+
+	checkOK(t, &betweenLevel{3}, Gt(&betweenLevel{2}))
+	checkError(t, &betweenLevel{1}, Gte(&betweenLevel{2}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("level 1"),
+			Expected: mustBe("≥ level 2"),
+		})
+}
+
 func TestBetweenTypeBehind(t *testing.T) {
 	equalTypes(t, Between(0, 10), 23)
 	equalTypes(t, Between(int64(0), int64(10)), int64(23))
@@ -429,4 +629,7 @@ func TestBetweenTypeBehind(t *testing.T) {
 	equalTypes(t, Gte(int32(23)), int32(0))
 	equalTypes(t, Lt(int32(23)), int32(0))
 	equalTypes(t, Lte(int32(23)), int32(0))
+
+	equalTypes(t, Gt("a"), "")
+	equalTypes(t, Gt(big.NewInt(1)), (*big.Int)(nil))
 }
@@ -41,6 +41,10 @@ var _ TestDeep = &tdCode{}
 //
 // This operator allows to handle any specific comparison not handled
 // by standard operators.
+//
+// CmpDeeply calls "fn" only once per compared value, whether the
+// test succeeds or not. But some operators, as Bag or Set, may call
+// it several times to find a matching item.
 func Code(fn interface{}) TestDeep {
 	vfn := reflect.ValueOf(fn)
 
@@ -101,6 +105,9 @@ func (c *tdCode) Match(ctx Context, got reflect.Value) *Error {
 		}
 	}
 
+	if !ctx.userCodeAllowed() {
+		return booleanError
+	}
 	ret := c.function.Call([]reflect.Value{got})
 	if ret[0].Bool() {
 		return nil
@@ -139,15 +146,22 @@ type tdCodeResult struct {
 	Reason string
 }
 
-var _ testDeepStringer = tdCodeResult{}
+var (
+	_ testDeepStringer   = tdCodeResult{}
+	_ formattersStringer = tdCodeResult{}
+)
 
 func (r tdCodeResult) _TestDeep() {}
 
 func (r tdCodeResult) String() string {
+	return r.stringWith(nil)
+}
+
+func (r tdCodeResult) stringWith(formatters formatterSet) string {
 	if r.Reason == "" {
 		return fmt.Sprintf("  value: %s\nit failed but didn't say why",
-			toString(r.Value))
+			toStringWith(formatters, r.Value))
 	}
 	return fmt.Sprintf("        value: %s\nit failed coz: %s",
-		toString(r.Value), r.Reason)
+		toStringWith(formatters, r.Value), r.Reason)
 }
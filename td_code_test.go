@@ -114,6 +114,54 @@ func TestCode(t *testing.T) {
 		"Code(func(int) (testdeep_test.MyBool, testdeep_test.MyString))")
 }
 
+func TestCodeCalledOnce(t *testing.T) {
+	var calls int
+	isTwelve := Code(func(n int) bool {
+		calls++
+		return n == 12
+	})
+
+	// Success
+	if !CmpDeeply(t, 12, isTwelve) {
+		t.Error("CmpDeeply should succeed")
+	}
+	if calls != 1 {
+		t.Errorf("Code function called %d times instead of 1", calls)
+	}
+
+	// Failure
+	calls = 0
+	if CmpDeeply(&testing.T{}, 13, isTwelve) {
+		t.Error("CmpDeeply should fail")
+	}
+	if calls != 1 {
+		t.Errorf("Code function called %d times instead of 1", calls)
+	}
+
+	// Failure, even if the operator is negated
+	calls = 0
+	if CmpDeeply(&testing.T{}, 12, Not(isTwelve)) {
+		t.Error("CmpDeeply should fail")
+	}
+	if calls != 1 {
+		t.Errorf("Code function called %d times instead of 1", calls)
+	}
+
+	// Failure, each alternative of Any being compared once
+	calls = 0
+	if CmpDeeply(&testing.T{}, 13, Any(isTwelve, 14)) {
+		t.Error("CmpDeeply should fail")
+	}
+	if calls != 1 {
+		t.Errorf("Code function called %d times instead of 1", calls)
+	}
+
+	// Panics of the function are not hidden
+	checkPanic(t, func() {
+		CmpDeeply(t, 12, Code(func(n int) bool { panic("boom") }))
+	}, "boom")
+}
+
 func TestCodeTypeBehind(t *testing.T) {
 	equalTypes(t, Code(func(n int) bool { return false }), nil)
 }
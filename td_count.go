@@ -0,0 +1,212 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type countKind uint8
+
+const (
+	countExactly countKind = iota
+	countAtLeast
+	countAtMost
+)
+
+type tdCount struct {
+	BaseOKNil
+	expected reflect.Value
+	count    reflect.Value // int or TestDeep operator
+	kind     countKind
+}
+
+var _ TestDeep = &tdCount{}
+
+// Count operator has to be applied on arrays, slices or maps or on
+// pointers on array/slice/map. It counts the items (or the map
+// values) matching "expectedValue", and compares this count to
+// "count".
+//
+// "count" can be an int value:
+//   Count(Gt(10), 2)
+// as well as an other operator:
+//   Count(Gt(10), Between(2, 4))
+//
+// In case of failure, the items matching "expectedValue" are listed.
+func Count(expectedValue interface{}, count interface{}) TestDeep {
+	c := tdCount{
+		BaseOKNil: NewBaseOKNil(3),
+		expected:  reflect.ValueOf(expectedValue),
+		count:     reflect.ValueOf(count),
+	}
+
+	switch count.(type) {
+	case int:
+		if c.count.Int() >= 0 {
+			return &c
+		}
+	case TestDeep:
+		return &c
+	}
+	panic("usage: Count(EXPECTED_VALUE, INT|TESTDEEP_OPERATOR)")
+}
+
+// AtLeast operator has to be applied on arrays, slices or maps or on
+// pointers on array/slice/map. It succeeds if at least "min" items
+// (or map values) match "expectedValue".
+//
+// It is a shortcut for Count(expectedValue, Gte(min)).
+func AtLeast(expectedValue interface{}, min int) TestDeep {
+	return newCountBound(expectedValue, min, countAtLeast)
+}
+
+// AtMost operator has to be applied on arrays, slices or maps or on
+// pointers on array/slice/map. It succeeds if at most "max" items (or
+// map values) match "expectedValue".
+//
+// It is a shortcut for Count(expectedValue, Lte(max)).
+func AtMost(expectedValue interface{}, max int) TestDeep {
+	return newCountBound(expectedValue, max, countAtMost)
+}
+
+func newCountBound(expectedValue interface{}, bound int, kind countKind) *tdCount {
+	c := tdCount{
+		BaseOKNil: NewBaseOKNil(4),
+		expected:  reflect.ValueOf(expectedValue),
+		count:     reflect.ValueOf(bound),
+		kind:      kind,
+	}
+	if bound < 0 {
+		panic(fmt.Sprintf("usage: %s(EXPECTED_VALUE, INT), INT must be >= 0",
+			c.GetLocation().Func))
+	}
+	return &c
+}
+
+func (c *tdCount) Match(ctx Context, got reflect.Value) *Error {
+	got, err := getKindValue(ctx, got, c,
+		"Slice OR Array OR Map OR *Slice OR *Array OR *Map",
+		reflect.Slice, reflect.Array, reflect.Map)
+	if err != nil {
+		return err
+	}
+
+	// Items matching c.expected, only computed when not in boolean context
+	var matching []string
+
+	count := 0
+	if got.Kind() == reflect.Map {
+		keys := got.MapKeys()
+		if !ctx.booleanError {
+			sort.Slice(keys, func(i, j int) bool {
+				return toString(keys[i]) < toString(keys[j])
+			})
+		}
+		for _, key := range keys {
+			if deepValueEqual(ctx.boolean(), got.MapIndex(key), c.expected) == nil {
+				count++
+				if !ctx.booleanError {
+					matching = append(matching, ctx.AddMapKey(key).Path())
+				}
+			}
+		}
+	} else {
+		gotLen := got.Len()
+		for idx := 0; idx < gotLen; idx++ {
+			if deepValueEqual(ctx.boolean(), got.Index(idx), c.expected) == nil {
+				count++
+				if !ctx.booleanError {
+					matching = append(matching, ctx.AddArrayIndex(idx).Path())
+				}
+			}
+		}
+	}
+
+	var ok bool
+	switch {
+	case c.count.Kind() != reflect.Int: // TestDeep operator
+		vcount := reflect.New(intType).Elem()
+		vcount.SetInt(int64(count))
+		ok = deepValueEqual(ctx.boolean(), vcount, c.count) == nil
+	case c.kind == countAtLeast:
+		ok = count >= int(c.count.Int())
+	case c.kind == countAtMost:
+		ok = count <= int(c.count.Int())
+	default:
+		ok = count == int(c.count.Int())
+	}
+	if ok {
+		return nil
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+
+	summary := bytes.NewBufferString("     got count: ")
+	fmt.Fprintf(summary, "%d\nexpected count: %s\nmatching items: ", // nolint: errcheck
+		count, c.countString())
+	if len(matching) == 0 {
+		summary.WriteString("(none)")
+	} else {
+		summary.WriteString(strings.Join(matching, ", "))
+	}
+
+	return &Error{
+		Context:  ctx,
+		Message:  "bad count of matching items",
+		Summary:  rawString(summary.String()),
+		Location: c.GetLocation(),
+	}
+}
+
+func (c *tdCount) countString() string {
+	switch c.kind {
+	case countAtLeast:
+		return fmt.Sprintf(">= %d", c.count.Int())
+	case countAtMost:
+		return fmt.Sprintf("<= %d", c.count.Int())
+	}
+	if c.count.Kind() == reflect.Int {
+		return fmt.Sprint(c.count.Int())
+	}
+	return toString(c.count)
+}
+
+func (c *tdCount) String() string {
+	var prefix string
+	switch c.kind {
+	case countAtLeast:
+		prefix = "AtLeast("
+	case countAtMost:
+		prefix = "AtMost("
+	default:
+		prefix = "Count("
+	}
+
+	content := toString(c.expected)
+	if strings.Contains(content, "\n") {
+		content = indentString(content, strings.Repeat(" ", len(prefix)))
+	}
+
+	var count string
+	if c.kind == countExactly {
+		count = c.countString()
+	} else {
+		count = fmt.Sprint(c.count.Int())
+	}
+	return prefix + content + ", " + count + ")"
+}
+
+func (c *tdCount) treeChildren() []treeChild {
+	return []treeChild{{value: c.expected}}
+}
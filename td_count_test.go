@@ -0,0 +1,117 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestCount(t *testing.T) {
+	type MyArray [5]int
+	type MySlice []int
+	type MyMap map[string]int
+
+	for idx, got := range []interface{}{
+		[]int{12, 3, 20, 1, 15},
+		MyArray{12, 3, 20, 1, 15},
+		&MySlice{12, 3, 20, 1, 15},
+		map[int]int{1: 12, 2: 3, 3: 20, 4: 1, 5: 15},
+		&MyMap{"a": 12, "b": 3, "c": 20, "d": 1, "e": 15},
+	} {
+		testName := fmt.Sprintf("Test #%d → %v", idx, got)
+
+		checkOK(t, got, Count(Gt(10), 3), testName)
+		checkOK(t, got, Count(Gt(100), 0), testName)
+		checkOK(t, got, Count(Gt(10), Between(2, 4)), testName)
+		checkOK(t, got, AtLeast(Gt(10), 3), testName)
+		checkOK(t, got, AtLeast(Gt(10), 0), testName)
+		checkOK(t, got, AtMost(Gt(10), 3), testName)
+		checkOK(t, got, AtMost(Gt(10), 5), testName)
+
+		checkError(t, got, Count(Gt(10), 2),
+			expectedError{
+				Message: mustBe("bad count of matching items"),
+				Path:    mustBe("DATA"),
+				Summary: mustContain("     got count: 3\nexpected count: 2\nmatching items: "),
+			},
+			testName)
+
+		checkError(t, got, AtLeast(Gt(100), 1),
+			expectedError{
+				Message: mustBe("bad count of matching items"),
+				Path:    mustBe("DATA"),
+				Summary: mustBe("     got count: 0\nexpected count: >= 1\nmatching items: (none)"),
+			},
+			testName)
+	}
+
+	checkError(t, []int{12, 3, 20, 1, 15}, AtMost(Gt(10), 2),
+		expectedError{
+			Message: mustBe("bad count of matching items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("     got count: 3\nexpected count: <= 2\n" +
+				"matching items: DATA[0], DATA[2], DATA[4]"),
+		})
+
+	checkError(t, map[string]int{"a": 12, "b": 3, "c": 20}, Count(Lt(10), Between(2, 4)),
+		expectedError{
+			Message: mustBe("bad count of matching items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("     got count: 1\nexpected count: 2 ≤ got ≤ 4\n" +
+				`matching items: DATA[(string) (len=1) "b"]`),
+		})
+
+	checkOK(t, []interface{}{nil, 1, nil}, Count(nil, 2))
+	checkOK(t, []int(nil), Count(1, 0))
+
+	//
+	// Bad types
+	checkError(t, "foo", Count(1, 1),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("string"),
+			Expected: mustBe("Slice OR Array OR Map OR *Slice OR *Array OR *Map"),
+		})
+	checkError(t, (*MySlice)(nil), AtLeast(1, 1),
+		expectedError{
+			Message:  mustBe("nil pointer"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil *testdeep_test.MySlice"),
+			Expected: mustBe("Slice OR Array OR Map OR *Slice OR *Array OR *Map"),
+		})
+	checkError(t, nil, AtMost(1, 1),
+		expectedError{
+			Message:  mustBe("nil value"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("Slice OR Array OR Map OR *Slice OR *Array OR *Map"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Count(1, -1) }, "usage: Count(")
+	checkPanic(t, func() { Count(1, "2") }, "usage: Count(")
+	checkPanic(t, func() { AtLeast(1, -1) }, "usage: AtLeast(")
+	checkPanic(t, func() { AtMost(1, -1) }, "usage: AtMost(")
+
+	//
+	// String
+	equalStr(t, Count(Gt(10), 2).String(), "Count(> 10, 2)")
+	equalStr(t, Count(12, Between(2, 4)).String(), "Count((int) 12, 2 ≤ got ≤ 4)")
+	equalStr(t, AtLeast(Gt(10), 2).String(), "AtLeast(> 10, 2)")
+	equalStr(t, AtMost(Gt(10), 2).String(), "AtMost(> 10, 2)")
+}
+
+func TestCountTypeBehind(t *testing.T) {
+	equalTypes(t, Count(6, 1), nil)
+	equalTypes(t, AtLeast(6, 1), nil)
+	equalTypes(t, AtMost(6, 1), nil)
+}
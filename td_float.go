@@ -0,0 +1,158 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type tdFloat struct {
+	Base
+	expected reflect.Value
+	tol      FloatMode // only tolerance fields are set
+	ulp      bool      // true for NULP, false for NRel
+}
+
+var _ TestDeep = &tdFloat{}
+
+// NRel operator compares a float or a complex data against "num",
+// with a relative tolerance: it succeeds if
+//   |got - num| ≤ relTol × max(|got|, |num|)
+// Complex numbers are compared using their modulus. "num" must be
+// the same kind as the compared value, but float32 and float64 (resp.
+// complex64 and complex128) can be compared together.
+//
+//   CmpDeeply(t, 1000.0000001, NRel(1000.0, 1e-9)) // succeeds
+//   CmpDeeply(t, 1000.001, NRel(1000.0, 1e-9))     // fails
+//
+// NaN and -0 are handled according to ContextConfig.FloatMode
+// NaNEqual and SignedZeros fields.
+//
+// TypeBehind method returns the reflect.Type of "num".
+func NRel(num interface{}, relTol float64) TestDeep {
+	f := newFloat(num, "usage: NRel(FLOAT|COMPLEX, REL_TOLERANCE)")
+	if relTol < 0 {
+		panic("NRel(FLOAT|COMPLEX, REL_TOLERANCE): REL_TOLERANCE must be >= 0")
+	}
+	f.tol.RelTol = relTol
+	return f
+}
+
+// NULP operator compares a float or a complex data against "num",
+// with a tolerance in units in the last place: it succeeds if at most
+// "ulps" representable floats separate the compared value from
+// "num". Complex numbers are compared part by part. "num" must be the
+// same type as the compared value, as the unit in the last place
+// depends on the float size.
+//
+//   a, b := 0.1, 0.2
+//   CmpDeeply(t, a+b, NULP(0.3, 1)) // succeeds
+//   CmpDeeply(t, a+b, NULP(0.3, 0)) // fails
+//
+// NaN and -0 are handled according to ContextConfig.FloatMode
+// NaNEqual and SignedZeros fields.
+//
+// TypeBehind method returns the reflect.Type of "num".
+func NULP(num interface{}, ulps uint) TestDeep {
+	f := newFloat(num, "usage: NULP(FLOAT|COMPLEX, ULPS)")
+	f.tol.ULPs = ulps
+	f.ulp = true
+	return f
+}
+
+func newFloat(num interface{}, usage string) *tdFloat {
+	f := tdFloat{
+		Base:     NewBase(4),
+		expected: reflect.ValueOf(num),
+	}
+
+	switch f.expected.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return &f
+	}
+	panic(usage)
+}
+
+// compatible returns true if "typ" can be compared to f expected
+// value.
+func (f *tdFloat) compatible(typ reflect.Type) bool {
+	expectedType := f.expected.Type()
+	if typ == expectedType {
+		return true
+	}
+
+	// Predeclared floats (resp. complex numbers) of different sizes
+	// can be compared if the tolerance does not depend on the size
+	if f.ulp || typ.PkgPath() != "" || expectedType.PkgPath() != "" {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return expectedType.Kind() == reflect.Float32 ||
+			expectedType.Kind() == reflect.Float64
+	case reflect.Complex64, reflect.Complex128:
+		return expectedType.Kind() == reflect.Complex64 ||
+			expectedType.Kind() == reflect.Complex128
+	}
+	return false
+}
+
+func (f *tdFloat) Match(ctx Context, got reflect.Value) *Error {
+	if !f.compatible(got.Type()) {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "type mismatch",
+			Got:      rawString(got.Type().String()),
+			Expected: rawString(f.expected.Type().String()),
+			Location: f.GetLocation(),
+		}
+	}
+
+	config := ctx.getConfig()
+	mode := f.tol
+	mode.NaNEqual = config.FloatMode.NaNEqual
+	mode.SignedZeros = config.FloatMode.SignedZeros
+
+	var ok bool
+	switch got.Kind() {
+	case reflect.Float32, reflect.Float64:
+		ok = mode.equal(got.Float(), f.expected.Float(), got.Type().Bits())
+	default: // reflect.Complex64, reflect.Complex128
+		ok = mode.equalComplex(got.Complex(), f.expected.Complex(),
+			got.Type().Bits())
+	}
+
+	if ok {
+		return nil
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "values differ",
+		Got:      rawString(floatString(got)),
+		Expected: rawString(f.String()),
+		Location: f.GetLocation(),
+	}
+}
+
+func (f *tdFloat) String() string {
+	if f.ulp {
+		return fmt.Sprintf("%v ± %d ULP", f.expected.Interface(), f.tol.ULPs)
+	}
+	return fmt.Sprintf("%v ± %v relative", f.expected.Interface(), f.tol.RelTol)
+}
+
+func (f *tdFloat) TypeBehind() reflect.Type {
+	return f.expected.Type()
+}
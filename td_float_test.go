@@ -0,0 +1,162 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestNRel(t *testing.T) {
+	checkOK(t, 1000.0000001, NRel(1000.0, 1e-9))
+	checkOK(t, -1000.0000001, NRel(-1000.0, 1e-9))
+	checkOK(t, 1e-20, NRel(1.0000000001e-20, 1e-9))
+	checkOK(t, 12.0, NRel(12.0, 0))
+	checkOK(t, float32(1000.0001), NRel(float32(1000), 1e-6))
+	checkOK(t, float32(1000.0001), NRel(1000.0, 1e-6))
+	checkOK(t, complex(1000, 0.0000001), NRel(complex(1000, 0), 1e-9))
+	checkOK(t, complex64(complex(1000, 0.0001)), NRel(complex(1000, 0), 1e-6))
+
+	checkError(t, 1000.001, NRel(1000.0, 1e-9),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("1000.001"),
+			Expected: mustBe("1000 ± 1e-09 relative"),
+		})
+	checkError(t, 1e-20, NRel(2e-20, 1e-9),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("1e-20"),
+			Expected: mustBe("2e-20 ± 1e-09 relative"),
+		})
+	checkError(t, math.Inf(1), NRel(1e308, 1),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got:     mustBe("+Inf"),
+		})
+	checkError(t, complex(1000, 1), NRel(complex(1000, 0), 1e-9),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(1000+1i)"),
+			Expected: mustBe("(1000+0i) ± 1e-09 relative"),
+		})
+
+	// Unexported struct field
+	type point struct{ x float32 }
+	checkError(t, point{x: 1000.1}, Struct(point{}, StructFields{
+		"x": NRel(float32(1000), 1e-6),
+	}),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA.x"),
+			Got:     mustBe("1000.1"),
+		})
+
+	checkError(t, 12, NRel(12.0, 1e-9),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("float64"),
+		})
+	checkError(t, 12.0, NRel(complex(12, 0), 1e-9),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("float64"),
+			Expected: mustBe("complex128"),
+		})
+
+	//
+	// NaN
+	checkError(t, math.NaN(), NRel(math.NaN(), 1e-9),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("NaN"),
+			Expected: mustBe("NaN ± 1e-09 relative"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { NRel(12, 1e-9) }, "usage: NRel(")
+	checkPanic(t, func() { NRel(12.0, -1) }, "REL_TOLERANCE must be >= 0")
+
+	//
+	// String
+	equalStr(t, NRel(12.0, 0).String(), "12 ± 0 relative")
+	equalStr(t, NRel(12.0, 1e-9).String(), "12 ± 1e-09 relative")
+}
+
+func TestNULP(t *testing.T) {
+	a, b := 0.1, 0.2
+	checkOK(t, a+b, NULP(0.3, 1))
+	checkOK(t, 1.0, NULP(math.Nextafter(1, 2), 1))
+	checkOK(t, 1.0, NULP(math.Nextafter(math.Nextafter(1, 0), 0), 2))
+	checkOK(t, math.Copysign(0, -1), NULP(0.0, 0))
+	checkOK(t, math.SmallestNonzeroFloat64, NULP(-math.SmallestNonzeroFloat64, 2))
+	checkOK(t, float32(1), NULP(math.Nextafter32(1, 2), 1))
+	checkOK(t, complex(1, a+b), NULP(complex(1, 0.3), 1))
+
+	checkError(t, a+b, NULP(0.3, 0),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("0.30000000000000004"),
+			Expected: mustBe("0.3 ± 0 ULP"),
+		})
+	checkError(t, math.SmallestNonzeroFloat64,
+		NULP(-math.SmallestNonzeroFloat64, 1),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("5e-324"),
+			Expected: mustBe("-5e-324 ± 1 ULP"),
+		})
+	checkError(t, math.MaxFloat64, NULP(math.Inf(1), 10),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+		})
+	checkError(t, complex(1.1, a+b), NULP(complex(1, 0.3), 1),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+		})
+
+	// Size matters, whatever the tolerance is
+	for _, ulps := range []uint{0, 1} {
+		checkError(t, float32(1), NULP(1.0, ulps),
+			expectedError{
+				Message:  mustBe("type mismatch"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("float32"),
+				Expected: mustBe("float64"),
+			})
+	}
+
+	//
+	// Bad usage
+	checkPanic(t, func() { NULP("12", 1) }, "usage: NULP(")
+
+	//
+	// String
+	equalStr(t, NULP(12.0, 0).String(), "12 ± 0 ULP")
+	equalStr(t, NULP(12.0, 3).String(), "12 ± 3 ULP")
+}
+
+func TestFloatTypeBehind(t *testing.T) {
+	equalTypes(t, NRel(12.0, 1e-9), float64(0))
+	equalTypes(t, NULP(float32(12), 1), float32(0))
+	equalTypes(t, NULP(complex64(12), 1), complex64(0))
+}
@@ -27,6 +27,18 @@ func newList(items ...interface{}) (ret tdList) {
 }
 
 func (l *tdList) String() string {
-	return sliceToBuffer(bytes.NewBufferString(l.GetLocation().Func), l.items).
-		String()
+	return l.stringToBuffer(&bytes.Buffer{}).String()
+}
+
+func (l *tdList) stringToBuffer(buf *bytes.Buffer) *bytes.Buffer {
+	buf.WriteString(l.GetLocation().Func)
+	return sliceToBuffer(buf, l.items)
+}
+
+func (l *tdList) treeChildren() []treeChild {
+	children := make([]treeChild, len(l.items))
+	for idx, item := range l.items {
+		children[idx].value = item
+	}
+	return children
 }
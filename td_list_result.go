@@ -0,0 +1,129 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// compactMaxLen is the maximum length of each value rendered in a
+// compact error.
+const compactMaxLen = 64
+
+// listMaxErrors is the maximum number of sub-errors listed in a
+// tdListResult, when ContextConfig.MaxItems is 0.
+const listMaxErrors = 10
+
+// tdListResult is the summary of an operator failure due to several
+// sub-errors, as All or Any ones.
+type tdListResult struct {
+	Got      reflect.Value
+	Expected TestDeep
+	Errors   []*Error
+	Omitted  int // number of sub-errors not listed in Errors
+}
+
+// maxListErrors returns the maximum number of sub-errors a
+// tdListResult can list according to the config of "ctx".
+func maxListErrors(ctx Context) int {
+	if max := ctx.getConfig().MaxItems; max > 0 {
+		return max
+	}
+	return listMaxErrors
+}
+
+var (
+	_ testDeepStringer   = tdListResult{}
+	_ formattersStringer = tdListResult{}
+)
+
+func (r tdListResult) _TestDeep() {}
+
+func (r tdListResult) String() string {
+	return r.stringWith(nil)
+}
+
+func (r tdListResult) stringWith(formatters formatterSet) string {
+	buf := bytes.NewBufferString("     got: ")
+	buf.WriteString(indentString(toStringWith(formatters, r.Got), "          "))
+	buf.WriteString("\nexpected: ")
+	buf.WriteString(
+		indentString(toStringWith(formatters, r.Expected), "          "))
+
+	for idx, err := range r.Errors {
+		if idx == 0 {
+			buf.WriteString("\n reasons: ")
+		} else {
+			buf.WriteString("\n          ")
+		}
+		buf.WriteString(err.compactString(formatters))
+	}
+	if r.Omitted > 0 {
+		buf.WriteString("\n          ")
+		if r.Omitted == 1 {
+			buf.WriteString("…(1 more reason)")
+		} else {
+			fmt.Fprintf(buf, "…(%d more reasons)", r.Omitted) // nolint: errcheck
+		}
+	}
+	return buf.String()
+}
+
+// compactString renders e on one line, each of its values being
+// reduced to compactMaxLen bytes.
+func (e *Error) compactString(formatters formatterSet) string {
+	buf := &bytes.Buffer{}
+
+	path := e.Context.Path()
+	if pos := strings.Index(e.Message, "%%"); pos >= 0 {
+		buf.WriteString(e.Message[:pos])
+		buf.WriteString(path)
+		buf.WriteString(e.Message[pos+2:])
+	} else {
+		buf.WriteString(path)
+		buf.WriteString(": ")
+		buf.WriteString(e.Message)
+	}
+
+	if e.Summary != nil {
+		buf.WriteString(" (")
+		buf.WriteString(compactValue(toStringWith(formatters, e.Summary)))
+		buf.WriteByte(')')
+	} else {
+		buf.WriteString(" (got: ")
+		buf.WriteString(compactValue(toStringWith(formatters, e.Got)))
+		buf.WriteString(", expected: ")
+		buf.WriteString(compactValue(toStringWith(formatters, e.Expected)))
+		buf.WriteByte(')')
+	}
+
+	if e.Location.IsInitialized() {
+		buf.WriteString(" [")
+		buf.WriteString(e.Location.String())
+		buf.WriteByte(']')
+	}
+	return buf.String()
+}
+
+// compactValue puts "str" on one line and truncates it to
+// compactMaxLen bytes.
+func compactValue(str string) string {
+	str = strings.Join(strings.Fields(str), " ")
+	if len(str) <= compactMaxLen {
+		return str
+	}
+
+	cut := compactMaxLen
+	for cut > 0 && !utf8.RuneStart(str[cut]) {
+		cut--
+	}
+	return str[:cut] + "…"
+}
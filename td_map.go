@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 type mapKind uint8
@@ -24,8 +25,10 @@ type tdMap struct {
 	Base
 	expectedModel   reflect.Value
 	expectedEntries []mapEntryInfo
-	kind            mapKind
-	isPtr           bool
+	// entries whose key is a TestDeep operator, sorted by key
+	keyOperators []mapEntryInfo
+	kind         mapKind
+	isPtr        bool
 }
 
 var _ TestDeep = &tdMap{}
@@ -39,6 +42,12 @@ type mapEntryInfo struct {
 // is a map whose each key is the expected entry key and the
 // corresponding value the expected entry value (which can be a
 // TestDeep operator as well as a zero value.)
+//
+// A key can also be a TestDeep operator, as in
+// MapEntries{Re("^tmp_"): Ignore()}. In this case, each compared map
+// key not explicitly expected and matching this key operator must
+// have a value matching the corresponding expected value. With Map
+// and SuperMapOf, each key operator has to match at least one key.
 type MapEntries map[interface{}]interface{}
 
 func newMap(model interface{}, entries MapEntries, kind mapKind) *tdMap {
@@ -75,12 +84,28 @@ func (m *tdMap) populateExpectedEntries(entries MapEntries) {
 	checkedEntries := make(map[interface{}]bool, len(entries))
 
 	keyType := m.expectedModel.Type().Key()
-	valueType := m.expectedModel.Type().Elem()
 
 	var entryInfo mapEntryInfo
 
 	for key, expectedValue := range entries {
 		vkey := reflect.ValueOf(key)
+
+		if keyOp, ok := key.(TestDeep); ok {
+			if typ := keyOp.TypeBehind(); typ != nil && !typ.AssignableTo(keyType) {
+				panic(fmt.Sprintf(
+					"expected key %s type mismatch: %s != model key type (%s)",
+					toString(key),
+					typ,
+					keyType))
+			}
+
+			m.keyOperators = append(m.keyOperators, mapEntryInfo{
+				key:      vkey,
+				expected: m.expectedValue(key, expectedValue),
+			})
+			continue
+		}
+
 		if !vkey.Type().AssignableTo(keyType) {
 			panic(fmt.Sprintf(
 				"expected key %s type mismatch: %s != model key type (%s)",
@@ -89,36 +114,16 @@ func (m *tdMap) populateExpectedEntries(entries MapEntries) {
 				keyType))
 		}
 
-		if expectedValue == nil {
-			switch valueType.Kind() {
-			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
-				reflect.Ptr, reflect.Slice:
-				entryInfo.expected = reflect.Zero(valueType) // change to a typed nil
-			default:
-				panic(fmt.Sprintf(
-					"expected key %s value cannot be nil as entries value type is %s",
-					toString(key),
-					valueType))
-			}
-		} else {
-			entryInfo.expected = reflect.ValueOf(expectedValue)
-
-			if _, ok := expectedValue.(TestDeep); !ok {
-				if !entryInfo.expected.Type().AssignableTo(valueType) {
-					panic(fmt.Sprintf(
-						"expected key %s value type mismatch: %s != model key type (%s)",
-						toString(key),
-						entryInfo.expected.Type(),
-						valueType))
-				}
-			}
-		}
-
+		entryInfo.expected = m.expectedValue(key, expectedValue)
 		entryInfo.key = vkey
 		m.expectedEntries = append(m.expectedEntries, entryInfo)
 		checkedEntries[vkey.Interface()] = true
 	}
 
+	sort.Slice(m.keyOperators, func(i, j int) bool {
+		return toString(m.keyOperators[i].key) < toString(m.keyOperators[j].key)
+	})
+
 	// Check entries in model
 	for _, vkey := range expectedKeys {
 		entryInfo.expected = m.expectedModel.MapIndex(vkey)
@@ -133,6 +138,37 @@ func (m *tdMap) populateExpectedEntries(entries MapEntries) {
 	}
 }
 
+// expectedValue returns "expectedValue" as the expected value of
+// entry "key", panicking if it is not compatible with m model.
+func (m *tdMap) expectedValue(key, expectedValue interface{}) reflect.Value {
+	valueType := m.expectedModel.Type().Elem()
+
+	if expectedValue == nil {
+		// change to a typed nil
+		vexpectedValue, ok := nilValue(valueType)
+		if !ok {
+			panic(fmt.Sprintf(
+				"expected key %s value cannot be nil as entries value type is %s",
+				toString(key),
+				valueType))
+		}
+		return vexpectedValue
+	}
+
+	vexpectedValue := reflect.ValueOf(expectedValue)
+
+	if _, ok := expectedValue.(TestDeep); !ok {
+		if !vexpectedValue.Type().AssignableTo(valueType) {
+			panic(fmt.Sprintf(
+				"expected key %s value type mismatch: %s != model key type (%s)",
+				toString(key),
+				vexpectedValue.Type(),
+				valueType))
+		}
+	}
+	return vexpectedValue
+}
+
 // Map operator compares the contents of a map against the non-zero
 // values of "model" (if any) and the values of "expectedEntries".
 //
@@ -239,7 +275,7 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 			continue
 		}
 
-		err = deepValueEqual(ctx.AddDepth("["+toString(entryInfo.key)+"]"),
+		err = deepValueEqual(ctx.AddMapKey(entryInfo.key),
 			got.MapIndex(entryInfo.key), entryInfo.expected)
 		if err != nil {
 			return err.SetLocationIfMissing(m)
@@ -247,11 +283,20 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 		foundKeys[entryInfo.key.Interface()] = true
 	}
 
+	// Remaining keys are checked against key operators
+	var unmatchedKeyOps []reflect.Value
+	if len(m.keyOperators) > 0 {
+		err, unmatchedKeyOps = m.matchKeyOperators(ctx, got, foundKeys)
+		if err != nil {
+			return err
+		}
+	}
+
 	const errorMessage = "comparing hash keys of %%"
 
 	// For SuperMapOf we don't care about extra keys
 	if m.kind == superMap {
-		if len(notFoundKeys) == 0 {
+		if len(notFoundKeys) == 0 && len(unmatchedKeyOps) == 0 {
 			return nil
 		}
 
@@ -262,8 +307,9 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 			Context: ctx,
 			Message: errorMessage,
 			Summary: tdSetResult{
-				Kind:    keysSetResult,
-				Missing: notFoundKeys,
+				Kind:      keysSetResult,
+				Missing:   notFoundKeys,
+				Unmatched: unmatchedKeyOps,
 			},
 			Location: m.GetLocation(),
 		}
@@ -276,7 +322,7 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 		}
 		// allMap
 
-		if len(notFoundKeys) == 0 {
+		if len(notFoundKeys) == 0 && len(unmatchedKeyOps) == 0 {
 			return nil
 		}
 
@@ -287,8 +333,9 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 			Context: ctx,
 			Message: errorMessage,
 			Summary: tdSetResult{
-				Kind:    keysSetResult,
-				Missing: notFoundKeys,
+				Kind:      keysSetResult,
+				Missing:   notFoundKeys,
+				Unmatched: unmatchedKeyOps,
 			},
 			Location: m.GetLocation(),
 		}
@@ -304,6 +351,9 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 		Missing: notFoundKeys,
 		Extra:   make([]reflect.Value, 0, got.Len()-len(foundKeys)),
 	}
+	if m.kind == allMap {
+		res.Unmatched = unmatchedKeyOps
+	}
 
 	for _, vkey := range got.MapKeys() {
 		if !foundKeys[vkey.Interface()] {
@@ -319,6 +369,59 @@ func (m *tdMap) Match(ctx Context, got reflect.Value) (err *Error) {
 	}
 }
 
+// matchKeyOperators checks each key of "got" absent from
+// "foundKeys" against m key operators. Each key matching one of them,
+// the first in m.keyOperators order, must have a value matching the
+// corresponding expected value. Such keys are then added to
+// "foundKeys". Returned key operators are the ones matching no keys,
+// only when they are significant, so not for SubMapOf.
+func (m *tdMap) matchKeyOperators(ctx Context, got reflect.Value,
+	foundKeys map[interface{}]bool) (*Error, []reflect.Value) {
+	gotKeys := got.MapKeys()
+	if !ctx.booleanError {
+		// Deterministic error reports
+		sort.Slice(gotKeys, func(i, j int) bool {
+			return toString(gotKeys[i]) < toString(gotKeys[j])
+		})
+	}
+
+	counts := make([]int, len(m.keyOperators))
+
+	for _, vkey := range gotKeys {
+		if foundKeys[vkey.Interface()] {
+			continue
+		}
+
+		for idx, keyOp := range m.keyOperators {
+			if deepValueEqual(ctx.boolean(), vkey, keyOp.key) != nil {
+				continue
+			}
+
+			err := deepValueEqual(ctx.AddMapKey(vkey),
+				got.MapIndex(vkey), keyOp.expected)
+			if err != nil {
+				return err.SetLocationIfMissing(m), nil
+			}
+
+			counts[idx]++
+			foundKeys[vkey.Interface()] = true
+			break
+		}
+	}
+
+	if m.kind == subMap {
+		return nil, nil
+	}
+
+	var unmatched []reflect.Value
+	for idx, count := range counts {
+		if count == 0 {
+			unmatched = append(unmatched, m.keyOperators[idx].key)
+		}
+	}
+	return nil, unmatched
+}
+
 func (m *tdMap) String() string {
 	buf := &bytes.Buffer{}
 
@@ -329,15 +432,17 @@ func (m *tdMap) String() string {
 
 	buf.WriteString(m.expectedTypeStr())
 
-	if len(m.expectedEntries) == 0 {
+	if len(m.expectedEntries) == 0 && len(m.keyOperators) == 0 {
 		buf.WriteString("{}")
 	} else {
 		buf.WriteString("{\n")
 
-		for _, entryInfo := range m.expectedEntries {
-			fmt.Fprintf(buf, "  %s: %s,\n", // nolint: errcheck
-				toString(entryInfo.key),
-				toString(entryInfo.expected))
+		for _, entries := range [][]mapEntryInfo{m.expectedEntries, m.keyOperators} {
+			for _, entryInfo := range entries {
+				buf.WriteString("  ")
+				valueToBuffer(buf, entryInfo.key, "  ").WriteString(": ")
+				valueToBuffer(buf, entryInfo.expected, "  ").WriteString(",\n")
+			}
 		}
 
 		buf.WriteByte('}')
@@ -350,6 +455,20 @@ func (m *tdMap) String() string {
 	return buf.String()
 }
 
+func (m *tdMap) treeChildren() []treeChild {
+	children := make([]treeChild, 0,
+		len(m.expectedEntries)+len(m.keyOperators))
+	for _, entries := range [][]mapEntryInfo{m.expectedEntries, m.keyOperators} {
+		for _, entryInfo := range entries {
+			children = append(children, treeChild{
+				name:  "[" + toString(entryInfo.key) + "]",
+				value: entryInfo.expected,
+			})
+		}
+	}
+	return children
+}
+
 func (s *tdMap) TypeBehind() reflect.Type {
 	if s.isPtr {
 		return reflect.New(s.expectedModel.Type()).Type()
@@ -14,6 +14,7 @@ import (
 type tdMapEach struct {
 	BaseOKNil
 	expected reflect.Value
+	keys     bool // true if keys are compared instead of values
 }
 
 var _ TestDeep = &tdMapEach{}
@@ -28,71 +29,60 @@ func MapEach(expectedValue interface{}) TestDeep {
 	}
 }
 
-func (m *tdMapEach) Match(ctx Context, got reflect.Value) (err *Error) {
-	if !got.IsValid() {
-		if ctx.booleanError {
-			return booleanError
-		}
-		return &Error{
-			Context:  ctx,
-			Message:  "nil value",
-			Got:      rawString("nil"),
-			Expected: rawString("Map OR *Map"),
-			Location: m.GetLocation(),
-		}
+// MapEachKey operator has to be applied on maps. It compares each key
+// of data map against expected value. During a match, all keys have
+// to match to succeed.
+//
+//   CmpDeeply(t, map[string]int{"id_1": 1, "id_2": 2},
+//     MapEachKey(HasPrefix("id_"))) // succeeds
+func MapEachKey(expectedKey interface{}) TestDeep {
+	return &tdMapEach{
+		BaseOKNil: NewBaseOKNil(3),
+		expected:  reflect.ValueOf(expectedKey),
+		keys:      true,
 	}
+}
 
-	switch got.Kind() {
-	case reflect.Ptr:
-		gotElem := got.Elem()
-		if !gotElem.IsValid() {
-			if ctx.booleanError {
-				return booleanError
-			}
-			return &Error{
-				Context:  ctx,
-				Message:  "nil pointer",
-				Got:      rawString("nil " + got.Type().String()),
-				Expected: rawString("Map OR *Map"),
-				Location: m.GetLocation(),
-			}
-		}
-
-		if gotElem.Kind() != reflect.Map {
-			break
-		}
-		got = gotElem
-		fallthrough
+func (m *tdMapEach) Match(ctx Context, got reflect.Value) (err *Error) {
+	got, err = getMap(ctx, got, m)
+	if err != nil {
+		return err
+	}
 
-	case reflect.Map:
-		for _, key := range got.MapKeys() {
-			err = deepValueEqual(ctx.AddDepth("["+toString(key)+"]"),
+	for _, key := range got.MapKeys() {
+		if m.keys {
+			err = deepValueEqual(ctx.AddMapKey(key), key, m.expected)
+		} else {
+			err = deepValueEqual(ctx.AddMapKey(key),
 				got.MapIndex(key), m.expected)
-			if err != nil {
-				return err.SetLocationIfMissing(m)
-			}
 		}
-		return nil
+		if err != nil {
+			return err.SetLocationIfMissing(m)
+		}
 	}
+	return nil
+}
 
-	if ctx.booleanError {
-		return booleanError
-	}
-	return &Error{
-		Context:  ctx,
-		Message:  "bad type",
-		Got:      rawString(got.Type().String()),
-		Expected: rawString("Map OR *Map"),
-		Location: m.GetLocation(),
-	}
+// getMap returns the map behind "got", dereferencing it if it is a
+// pointer. An error located at "td" is returned if "got" is not a map
+// nor a pointer on a map.
+func getMap(ctx Context, got reflect.Value, td TestDeep) (reflect.Value, *Error) {
+	return getKindValue(ctx, got, td, "Map OR *Map", reflect.Map)
 }
 
 func (m *tdMapEach) String() string {
-	const prefix = "MapEach("
+	prefix := "MapEach("
+	if m.keys {
+		prefix = "MapEachKey("
+	}
 
 	content := toString(m.expected)
 	if strings.Contains(content, "\n") {
-		return prefix + indentString(content, "        ") + ")"
+		return prefix + indentString(content, strings.Repeat(" ", len(prefix))) + ")"
 	}
 	return prefix + content + ")"
 }
+
+func (m *tdMapEach) treeChildren() []treeChild {
+	return []treeChild{{value: m.expected}}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+)
+
+type tdMapEachEntry struct {
+	BaseOKNil
+	function reflect.Value
+	keyType  reflect.Type
+	valType  reflect.Type
+}
+
+var _ TestDeep = &tdMapEachEntry{}
+
+// MapEachEntry operator has to be applied on maps. It calls "fn" for
+// each entry of data map, so invariants involving keys and values can
+// be checked. "fn" must take two parameters whose types must be
+// compatible with respectively the key and the value types of the
+// compared map.
+//
+// As for Code operator, "fn" can return a single bool kind value,
+// telling that yes or no the entry is OK:
+//   MapEachEntry(func (id int, user *User) bool {
+//       return user.ID == id
+//     })
+//
+// or two values (bool, string) kinds. The bool value has the same
+// meaning as above, and the string value is used to describe the
+// test when it fails:
+//   MapEachEntry(func (id int, user *User) (bool, string) {
+//       if user.ID == id {
+//         return true, ""
+//       }
+//       return false, "ID field must be the same as the key"
+//     })
+//
+// During a match, all entries have to be OK to succeed.
+func MapEachEntry(fn interface{}) TestDeep {
+	vfn := reflect.ValueOf(fn)
+
+	if vfn.Kind() != reflect.Func {
+		panic("usage: MapEachEntry(FUNC)")
+	}
+
+	fnType := vfn.Type()
+	if fnType.NumIn() != 2 {
+		panic("MapEachEntry(FUNC): FUNC must take two arguments")
+	}
+
+	switch fnType.NumOut() {
+	case 2:
+		if fnType.Out(1).Kind() != reflect.String {
+			break
+		}
+		fallthrough
+
+	case 1:
+		if fnType.Out(0).Kind() == reflect.Bool {
+			return &tdMapEachEntry{
+				BaseOKNil: NewBaseOKNil(3),
+				function:  vfn,
+				keyType:   fnType.In(0),
+				valType:   fnType.In(1),
+			}
+		}
+	}
+
+	panic("MapEachEntry(FUNC): FUNC must return bool or (bool, string)")
+}
+
+func (m *tdMapEachEntry) Match(ctx Context, got reflect.Value) *Error {
+	got, err := getMap(ctx, got, m)
+	if err != nil {
+		return err
+	}
+
+	if !got.Type().Key().AssignableTo(m.keyType) ||
+		!got.Type().Elem().AssignableTo(m.valType) {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "incompatible parameter types",
+			Got:      rawString(got.Type().String()),
+			Expected: rawString(reflect.MapOf(m.keyType, m.valType).String()),
+			Location: m.GetLocation(),
+		}
+	}
+
+	// Refuse to override unexported fields access, as Code does
+	if !got.CanInterface() {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "cannot compare unexported field",
+			Summary:  rawString("use Code() on surrounding struct instead"),
+			Location: m.GetLocation(),
+		}
+	}
+
+	for _, key := range got.MapKeys() {
+		value := got.MapIndex(key)
+
+		if !ctx.userCodeAllowed() {
+			return booleanError
+		}
+
+		ret := m.function.Call([]reflect.Value{key, value})
+		if ret[0].Bool() {
+			continue
+		}
+
+		if ctx.booleanError {
+			return booleanError
+		}
+
+		err := Error{
+			Context:  ctx.AddMapKey(key),
+			Message:  "ran code with %% entry as arguments",
+			Location: m.GetLocation(),
+		}
+
+		if len(ret) > 1 {
+			err.Summary = tdCodeResult{
+				Value:  value,
+				Reason: ret[1].String(),
+			}
+		} else {
+			err.Summary = tdCodeResult{
+				Value: value,
+			}
+		}
+		return &err
+	}
+	return nil
+}
+
+func (m *tdMapEachEntry) String() string {
+	return "MapEachEntry(" + m.function.Type().String() + ")"
+}
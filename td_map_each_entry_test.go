@@ -0,0 +1,112 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestMapEachEntry(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	type MyMap map[int]*user
+
+	got := MyMap{
+		1: {ID: 1, Name: "Alice"},
+		2: {ID: 2, Name: "Bob"},
+	}
+
+	checkOK(t, got, MapEachEntry(func(id int, u *user) bool {
+		return u.ID == id
+	}))
+	checkOK(t, &got, MapEachEntry(func(id int, u interface{}) bool {
+		return u.(*user).ID == id
+	}))
+	checkOK(t, map[string]int{}, MapEachEntry(func(k string, v int) bool {
+		return false
+	}))
+
+	got[3] = &user{ID: 4, Name: "Charlie"}
+
+	checkError(t, got,
+		MapEachEntry(func(id int, u *user) bool { return u.ID == id }),
+		expectedError{
+			Message: mustBe("ran code with %% entry as arguments"),
+			Path:    mustBe("DATA[(int) 3]"),
+			Summary: mustMatch(`(?s)^  value: \(\*testdeep_test\.user\).*Charlie.*\nit failed but didn't say why\z`),
+		})
+
+	checkError(t, got,
+		MapEachEntry(func(id int, u *user) (bool, string) {
+			if u.ID != id {
+				return false, "ID mismatch"
+			}
+			return true, ""
+		}),
+		expectedError{
+			Message: mustBe("ran code with %% entry as arguments"),
+			Path:    mustBe("DATA[(int) 3]"),
+			Summary: mustMatch(`\nit failed coz: ID mismatch\z`),
+		})
+
+	checkError(t, got,
+		MapEachEntry(func(id string, u *user) bool { return true }),
+		expectedError{
+			Message:  mustBe("incompatible parameter types"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("testdeep_test.MyMap"),
+			Expected: mustBe("map[string]*testdeep_test.user"),
+		})
+
+	checkError(t, (*MyMap)(nil),
+		MapEachEntry(func(id int, u *user) bool { return true }),
+		expectedError{
+			Message:  mustBe("nil pointer"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil *testdeep_test.MyMap"),
+			Expected: mustBe("Map OR *Map"),
+		})
+
+	checkError(t, []int{1},
+		MapEachEntry(func(id int, u *user) bool { return true }),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("[]int"),
+			Expected: mustBe("Map OR *Map"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { MapEachEntry("test") }, "usage: MapEachEntry")
+
+	checkPanic(t, func() {
+		MapEachEntry(func(k int) bool { return true })
+	}, "FUNC must take two arguments")
+
+	checkPanic(t, func() {
+		MapEachEntry(func(k, v int) int { return 0 })
+	}, "FUNC must return bool or (bool, string)")
+
+	checkPanic(t, func() {
+		MapEachEntry(func(k, v int) (bool, int) { return true, 0 })
+	}, "FUNC must return bool or (bool, string)")
+
+	//
+	// String
+	equalStr(t,
+		MapEachEntry(func(k string, v int) bool { return true }).String(),
+		"MapEachEntry(func(string, int) bool)")
+}
+
+func TestMapEachEntryTypeBehind(t *testing.T) {
+	equalTypes(t, MapEachEntry(func(k string, v int) bool { return true }), nil)
+}
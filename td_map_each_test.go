@@ -108,6 +108,54 @@ func TestMapEach(t *testing.T) {
             (int) 2))`)
 }
 
+func TestMapEachKey(t *testing.T) {
+	type MyMap map[string]int
+
+	checkOK(t, map[string]int{"id_1": 1, "id_2": 2},
+		MapEachKey(HasPrefix("id_")))
+	checkOK(t, &MyMap{"id_1": 1, "id_2": 2}, MapEachKey(Len(4)))
+	checkOK(t, map[int]bool{}, MapEachKey(12))
+
+	checkError(t, map[string]int{"id_1": 1, "xx_2": 2},
+		MapEachKey(HasPrefix("id_")),
+		expectedError{
+			Message:  mustBe("has not prefix"),
+			Path:     mustBe(`DATA[(string) (len=4) "xx_2"]`),
+			Got:      mustBe(`(string) (len=4) "xx_2"`),
+			Expected: mustBe(`HasPrefix((string) (len=3) "id_")`),
+		})
+
+	checkError(t, map[int]string{1: "a", 3: "c"}, MapEachKey(Lt(3)),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA[(int) 3]"),
+			Got:      mustBe("3"),
+			Expected: mustBe("< 3"),
+		})
+
+	checkError(t, nil, MapEachKey(4), expectedError{
+		Message:  mustBe("nil value"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("nil"),
+		Expected: mustBe("Map OR *Map"),
+	})
+
+	checkError(t, 666, MapEachKey(4), expectedError{
+		Message:  mustBe("bad type"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("int"),
+		Expected: mustBe("Map OR *Map"),
+	})
+
+	//
+	// String
+	equalStr(t, MapEachKey(4).String(), "MapEachKey((int) 4)")
+	equalStr(t, MapEachKey(All(1, 2)).String(),
+		`MapEachKey(All((int) 1,
+               (int) 2))`)
+}
+
 func TestMapEachTypeBehind(t *testing.T) {
 	equalTypes(t, MapEach(4), nil)
+	equalTypes(t, MapEachKey(4), nil)
 }
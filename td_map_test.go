@@ -304,6 +304,103 @@ func TestMap(t *testing.T) {
 })`)
 }
 
+func TestMapKeyOperators(t *testing.T) {
+	gotMap := map[string]int{"foo": 1, "bar": 2, "tmp_a": 3, "tmp_b": 4}
+
+	checkOK(t, gotMap, Map(map[string]int{"foo": 1}, MapEntries{
+		"bar":       2,
+		Re("^tmp_"): Between(3, 4),
+	}))
+
+	// Explicit keys take precedence
+	checkOK(t, gotMap, Map(map[string]int{}, MapEntries{
+		"tmp_a":          3,
+		Re("^tmp_"):      4,
+		Re("^[a-z]{3}$"): Ignore(),
+	}))
+
+	checkError(t, gotMap, Map(map[string]int{"foo": 1, "bar": 2}, MapEntries{
+		Re("^tmp_"): 3,
+	}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe(`DATA[(string) (len=5) "tmp_b"]`),
+			Got:      mustBe("(int) 4"),
+			Expected: mustBe("(int) 3"),
+		})
+
+	checkError(t, gotMap, Map(map[string]int{"foo": 1, "bar": 2}, MapEntries{
+		Re("^tmp_"):  Ignore(),
+		Re("^zip_"):  Ignore(),
+		Re("^_none"): Ignore(),
+	}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Operators matching no keys: (^_none,
+                             ^zip_)`),
+		})
+
+	checkError(t, gotMap, Map(map[string]int{"foo": 1}, MapEntries{
+		Re("^tmp_"): Ignore(),
+		Re("^zip_"): Ignore(),
+	}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra keys: ((string) (len=3) "bar")
+  Operators matching no keys: (^zip_)`),
+		})
+
+	// SubMapOf: key operators can match nothing
+	checkOK(t, gotMap, SubMapOf(map[string]int{"foo": 1, "bar": 2, "zip": 3},
+		MapEntries{
+			Re("^tmp_"): Gt(2),
+			Re("^zip_"): 12,
+		}))
+
+	checkError(t, gotMap, SubMapOf(map[string]int{"foo": 1, "bar": 2},
+		MapEntries{Re("^tmp_a"): 3}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra keys: ((string) (len=5) "tmp_b")`),
+		})
+
+	// SuperMapOf: each key operator has to match at least one key
+	checkOK(t, gotMap, SuperMapOf(map[string]int{"foo": 1},
+		MapEntries{Re("^tmp_"): Gt(2)}))
+
+	checkError(t, gotMap, SuperMapOf(map[string]int{"foo": 1},
+		MapEntries{
+			"test":      3,
+			Re("^zip_"): 12,
+		}),
+		expectedError{
+			Message: mustBe("comparing hash keys of %%"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Missing keys: ((string) (len=4) "test")
+  Operators matching no keys: (^zip_)`),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t,
+		func() { Map(map[string]int{}, MapEntries{Between(1, 2): 3}) },
+		"expected key 1 ≤ got ≤ 2 type mismatch: int != model key type (string)")
+	checkPanic(t,
+		func() { Map(map[string]int{}, MapEntries{Re("^tmp_"): "foo"}) },
+		"expected key ^tmp_ value type mismatch: string != model key type (int)")
+
+	//
+	// String
+	equalStr(t, Map(map[string]int{"foo": 1}, MapEntries{Re("^tmp_"): 3}).String(),
+		`map[string]int{
+  (string) (len=3) "foo": (int) 1,
+  ^tmp_: (int) 3,
+}`)
+}
+
 func TestMapTypeBehind(t *testing.T) {
 	type MyMap map[string]int
 
@@ -0,0 +1,236 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"math"
+	"reflect"
+)
+
+// floatParts returns the float parts of "got": itself for a float,
+// its real and imaginary parts for a complex number. The second
+// value is false if "got" is neither a float nor a complex number.
+func floatParts(got reflect.Value) ([]float64, bool) {
+	switch got.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return []float64{got.Float()}, true
+	case reflect.Complex64, reflect.Complex128:
+		c := got.Complex()
+		return []float64{real(c), imag(c)}, true
+	}
+	return nil, false
+}
+
+// floatGot returns "got" float or complex number as displayed in
+// errors. Contrary to the default rendering, the sign of special
+// values in complex numbers is always displayed, as in "(1+NaNi)".
+func floatGot(got reflect.Value) rawString {
+	return rawString("(" + got.Type().String() + ") " + floatString(got))
+}
+
+// floatBadType returns the error reported by NaN, NotNaN, Inf and
+// Finite operators when "got" is neither a float nor a complex
+// number.
+func floatBadType(ctx Context, got reflect.Value, location Location) *Error {
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "bad type",
+		Got:      rawString(got.Type().String()),
+		Expected: rawString("float32, float64, complex64 or complex128"),
+		Location: location,
+	}
+}
+
+type tdNaN struct {
+	Base
+}
+
+var _ TestDeep = &tdNaN{}
+
+// NaN operator checks that data is a float and is NaN (not a
+// number). A complex number is NaN if its real or imaginary part is
+// NaN.
+//
+// It is not affected by ContextConfig.FloatMode.NaNEqual field, NaN
+// never matching anything else.
+func NaN() TestDeep {
+	return &tdNaN{
+		Base: NewBase(3),
+	}
+}
+
+func (n *tdNaN) Match(ctx Context, got reflect.Value) *Error {
+	parts, ok := floatParts(got)
+	if !ok {
+		return floatBadType(ctx, got, n.GetLocation())
+	}
+
+	for _, part := range parts {
+		if math.IsNaN(part) {
+			return nil
+		}
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "not NaN",
+		Got:      floatGot(got),
+		Expected: n,
+		Location: n.GetLocation(),
+	}
+}
+
+func (n *tdNaN) String() string {
+	return "NaN"
+}
+
+type tdNotNaN struct {
+	Base
+}
+
+var _ TestDeep = &tdNotNaN{}
+
+// NotNaN operator checks that data is a float and is not NaN (not a
+// number). A complex number is not NaN if none of its real and
+// imaginary parts is NaN.
+func NotNaN() TestDeep {
+	return &tdNotNaN{
+		Base: NewBase(3),
+	}
+}
+
+func (n *tdNotNaN) Match(ctx Context, got reflect.Value) *Error {
+	parts, ok := floatParts(got)
+	if !ok {
+		return floatBadType(ctx, got, n.GetLocation())
+	}
+
+	for _, part := range parts {
+		if math.IsNaN(part) {
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  ctx,
+				Message:  "NaN value",
+				Got:      floatGot(got),
+				Expected: n,
+				Location: n.GetLocation(),
+			}
+		}
+	}
+	return nil
+}
+
+func (n *tdNotNaN) String() string {
+	return "not NaN"
+}
+
+type tdInf struct {
+	Base
+	sign int
+}
+
+var _ TestDeep = &tdInf{}
+
+// Inf operator checks that data is a float and is an infinity,
+// according to "sign": +Inf if "sign" > 0, -Inf if "sign" < 0, any
+// infinity if "sign" == 0. A complex number is infinite if its real
+// or imaginary part is.
+//
+//   CmpDeeply(t, math.Inf(1), Inf(1))  // succeeds
+//   CmpDeeply(t, math.Inf(-1), Inf(0)) // succeeds
+//   CmpDeeply(t, math.Inf(-1), Inf(1)) // fails
+func Inf(sign int) TestDeep {
+	return &tdInf{
+		Base: NewBase(3),
+		sign: sign,
+	}
+}
+
+func (n *tdInf) Match(ctx Context, got reflect.Value) *Error {
+	parts, ok := floatParts(got)
+	if !ok {
+		return floatBadType(ctx, got, n.GetLocation())
+	}
+
+	for _, part := range parts {
+		if math.IsInf(part, n.sign) {
+			return nil
+		}
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "not infinite",
+		Got:      floatGot(got),
+		Expected: n,
+		Location: n.GetLocation(),
+	}
+}
+
+func (n *tdInf) String() string {
+	switch {
+	case n.sign > 0:
+		return "+Inf"
+	case n.sign < 0:
+		return "-Inf"
+	default:
+		return "±Inf"
+	}
+}
+
+type tdFinite struct {
+	Base
+}
+
+var _ TestDeep = &tdFinite{}
+
+// Finite operator checks that data is a float and is neither NaN nor
+// an infinity. A complex number is finite if both its real and
+// imaginary parts are.
+func Finite() TestDeep {
+	return &tdFinite{
+		Base: NewBase(3),
+	}
+}
+
+func (n *tdFinite) Match(ctx Context, got reflect.Value) *Error {
+	parts, ok := floatParts(got)
+	if !ok {
+		return floatBadType(ctx, got, n.GetLocation())
+	}
+
+	for _, part := range parts {
+		if math.IsNaN(part) || math.IsInf(part, 0) {
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  ctx,
+				Message:  "not finite",
+				Got:      floatGot(got),
+				Expected: n,
+				Location: n.GetLocation(),
+			}
+		}
+	}
+	return nil
+}
+
+func (n *tdFinite) String() string {
+	return "finite"
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestNaN(t *testing.T) {
+	nan := math.NaN()
+
+	checkOK(t, nan, NaN())
+	checkOK(t, float32(nan), NaN())
+	checkOK(t, complex(nan, 1), NaN())
+	checkOK(t, complex64(complex(1, nan)), NaN())
+
+	type myFloat float64
+	checkOK(t, myFloat(nan), NaN())
+
+	checkOK(t, struct{ Mean float64 }{nan},
+		Struct(struct{ Mean float64 }{}, StructFields{"Mean": NaN()}))
+
+	// Unexported struct field
+	type stats struct{ mean float32 }
+	checkError(t, stats{mean: 1.5}, Struct(stats{}, StructFields{"mean": NaN()}),
+		expectedError{
+			Message:  mustBe("not NaN"),
+			Path:     mustBe("DATA.mean"),
+			Got:      mustBe("(float32) 1.5"),
+			Expected: mustBe("NaN"),
+		})
+
+	checkError(t, 12.5, NaN(),
+		expectedError{
+			Message:  mustBe("not NaN"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) 12.5"),
+			Expected: mustBe("NaN"),
+		})
+	checkError(t, math.Inf(1), NaN(),
+		expectedError{
+			Message:  mustBe("not NaN"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) +Inf"),
+			Expected: mustBe("NaN"),
+		})
+	checkError(t, 12, NaN(),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("float32, float64, complex64 or complex128"),
+		})
+	checkError(t, nil, NaN(),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("NaN"),
+		})
+
+	//
+	// String
+	equalStr(t, NaN().String(), "NaN")
+}
+
+func TestNotNaN(t *testing.T) {
+	nan := math.NaN()
+
+	checkOK(t, 12.5, NotNaN())
+	checkOK(t, float32(0), NotNaN())
+	checkOK(t, math.Inf(-1), NotNaN())
+	checkOK(t, complex(1, 2), NotNaN())
+
+	checkError(t, nan, NotNaN(),
+		expectedError{
+			Message:  mustBe("NaN value"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) NaN"),
+			Expected: mustBe("not NaN"),
+		})
+	checkError(t, complex(1, nan), NotNaN(),
+		expectedError{
+			Message:  mustBe("NaN value"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(complex128) (1+NaNi)"),
+			Expected: mustBe("not NaN"),
+		})
+	checkError(t, "NaN", NotNaN(),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("string"),
+			Expected: mustBe("float32, float64, complex64 or complex128"),
+		})
+
+	//
+	// String
+	equalStr(t, NotNaN().String(), "not NaN")
+}
+
+func TestInf(t *testing.T) {
+	plusInf, minusInf := math.Inf(1), math.Inf(-1)
+
+	checkOK(t, plusInf, Inf(1))
+	checkOK(t, plusInf, Inf(0))
+	checkOK(t, minusInf, Inf(-1))
+	checkOK(t, minusInf, Inf(0))
+	checkOK(t, float32(plusInf), Inf(1))
+	checkOK(t, complex(1, minusInf), Inf(-1))
+
+	checkError(t, minusInf, Inf(1),
+		expectedError{
+			Message:  mustBe("not infinite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) -Inf"),
+			Expected: mustBe("+Inf"),
+		})
+	checkError(t, plusInf, Inf(-1),
+		expectedError{
+			Message:  mustBe("not infinite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) +Inf"),
+			Expected: mustBe("-Inf"),
+		})
+	checkError(t, math.MaxFloat64, Inf(0),
+		expectedError{
+			Message:  mustBe("not infinite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) 1.7976931348623157e+308"),
+			Expected: mustBe("±Inf"),
+		})
+	checkError(t, math.NaN(), Inf(0),
+		expectedError{
+			Message:  mustBe("not infinite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) NaN"),
+			Expected: mustBe("±Inf"),
+		})
+	checkError(t, uint8(1), Inf(0),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("uint8"),
+			Expected: mustBe("float32, float64, complex64 or complex128"),
+		})
+
+	//
+	// String
+	equalStr(t, Inf(2).String(), "+Inf")
+	equalStr(t, Inf(-2).String(), "-Inf")
+	equalStr(t, Inf(0).String(), "±Inf")
+}
+
+func TestFinite(t *testing.T) {
+	checkOK(t, 12.5, Finite())
+	checkOK(t, float32(-math.MaxFloat32), Finite())
+	checkOK(t, complex(1, 2), Finite())
+
+	checkError(t, math.Inf(1), Finite(),
+		expectedError{
+			Message:  mustBe("not finite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) +Inf"),
+			Expected: mustBe("finite"),
+		})
+	checkError(t, math.NaN(), Finite(),
+		expectedError{
+			Message:  mustBe("not finite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(float64) NaN"),
+			Expected: mustBe("finite"),
+		})
+	checkError(t, complex64(complex(math.Inf(-1), 0)), Finite(),
+		expectedError{
+			Message:  mustBe("not finite"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("(complex64) (-Inf+0i)"),
+			Expected: mustBe("finite"),
+		})
+	checkError(t, true, Finite(),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("bool"),
+			Expected: mustBe("float32, float64, complex64 or complex128"),
+		})
+
+	//
+	// String
+	equalStr(t, Finite().String(), "finite")
+}
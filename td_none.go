@@ -9,6 +9,8 @@ package testdeep
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 type tdNone struct {
@@ -19,6 +21,9 @@ var _ TestDeep = &tdNone{}
 
 // None operator compares data against several expected values. During
 // a match, none of them have to match to succeed.
+//
+// In case of failure, all the expected values that matched are
+// reported.
 func None(expectedValues ...interface{}) TestDeep {
 	return &tdNone{
 		tdList: newList(expectedValues...),
@@ -38,27 +43,37 @@ func Not(expected interface{}) TestDeep {
 }
 
 func (n *tdNone) Match(ctx Context, got reflect.Value) *Error {
+	var parts []string
+
 	for idx, item := range n.items {
-		if deepValueEqualOK(got, item) {
+		if deepValueEqual(ctx.boolean(), got, item) == nil {
 			if ctx.booleanError {
 				return booleanError
 			}
-
-			var mesg string
-			if n.GetLocation().Func == "Not" {
-				mesg = "comparing with Not"
-			} else {
-				mesg = fmt.Sprintf("comparing with None (part %d of %d is OK)",
-					idx+1, len(n.items))
-			}
-			return &Error{
-				Context:  ctx,
-				Message:  mesg,
-				Got:      got,
-				Expected: n,
-				Location: n.GetLocation(),
-			}
+			parts = append(parts, strconv.Itoa(idx+1))
 		}
 	}
-	return nil
+
+	if parts == nil {
+		return nil
+	}
+
+	var mesg string
+	switch {
+	case n.GetLocation().Func == "Not":
+		mesg = "comparing with Not"
+	case len(parts) == 1:
+		mesg = fmt.Sprintf("comparing with None (part %s of %d is OK)",
+			parts[0], len(n.items))
+	default:
+		mesg = fmt.Sprintf("comparing with None (parts %s of %d are OK)",
+			strings.Join(parts, ", "), len(n.items))
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  mesg,
+		Got:      got,
+		Expected: n,
+		Location: n.GetLocation(),
+	}
 }
@@ -30,6 +30,13 @@ func TestNone(t *testing.T) {
 		Expected: mustBe("None((int) 7,\n     nil)"),
 	})
 
+	checkError(t, 6, None(6, 7, Between(5, 8)), expectedError{
+		Message:  mustBe("comparing with None (parts 1, 3 of 3 are OK)"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("(int) 6"),
+		Expected: mustBe("None((int) 6,\n     (int) 7,\n     5 ≤ got ≤ 8)"),
+	})
+
 	//
 	// String
 	equalStr(t, None(6).String(), "None((int) 6)")
@@ -141,12 +141,8 @@ func (r *tdRe) matchStringCaptures(ctx Context, got string, result [][]string) *
 }
 
 func (r *tdRe) matchCaptures(ctx Context, captures []string) *Error {
-	newCtx := NewContext("(" + ctx.path + " =~ " + r.String() + ")")
-	if ctx.booleanError {
-		newCtx.booleanError = true
-	}
-
-	return deepValueEqual(newCtx, reflect.ValueOf(captures), r.captures)
+	return deepValueEqual(ctx.addCaptures(r.String()),
+		reflect.ValueOf(captures), r.captures)
 }
 
 func (r *tdRe) matchBool(ctx Context, got interface{}, result bool) *Error {
@@ -234,3 +230,10 @@ func (r *tdRe) Match(ctx Context, got reflect.Value) *Error {
 func (r *tdRe) String() string {
 	return r.re.String()
 }
+
+func (r *tdRe) treeChildren() []treeChild {
+	if !r.captures.IsValid() {
+		return nil
+	}
+	return []treeChild{{name: "captures", value: r.captures}}
+}
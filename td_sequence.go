@@ -0,0 +1,159 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"reflect"
+)
+
+type sequenceKind uint8
+
+const (
+	prefixSequence sequenceKind = iota
+	suffixSequence
+	subSequence
+)
+
+type tdSequence struct {
+	tdList
+	kind sequenceKind
+}
+
+var _ TestDeep = &tdSequence{}
+
+// SliceHasPrefix operator checks that an array or a slice (or a
+// pointer on array/slice) starts with "expectedItems", in the same
+// order. Each expected item can be a TestDeep operator.
+//
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasPrefix(1, 2))  // succeeds
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasPrefix(Gt(0))) // succeeds
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasPrefix(2, 3))  // fails
+//   CmpDeeply(t, []int{1}, SliceHasPrefix(1, 2))        // fails
+func SliceHasPrefix(expectedItems ...interface{}) TestDeep {
+	return &tdSequence{
+		tdList: newList(expectedItems...),
+		kind:   prefixSequence,
+	}
+}
+
+// SliceHasSuffix operator checks that an array or a slice (or a
+// pointer on array/slice) ends with "expectedItems", in the same
+// order. Each expected item can be a TestDeep operator.
+//
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasSuffix(2, 3))  // succeeds
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasSuffix(Gt(2))) // succeeds
+//   CmpDeeply(t, []int{1, 2, 3}, SliceHasSuffix(1, 2))  // fails
+func SliceHasSuffix(expectedItems ...interface{}) TestDeep {
+	return &tdSequence{
+		tdList: newList(expectedItems...),
+		kind:   suffixSequence,
+	}
+}
+
+// Subsequence operator checks that an array or a slice (or a pointer
+// on array/slice) contains "expectedItems" in the same order, but not
+// necessarily contiguously. Each expected item can be a TestDeep
+// operator.
+//
+//   CmpDeeply(t, []int{1, 2, 3, 4}, Subsequence(1, 3))     // succeeds
+//   CmpDeeply(t, []int{1, 2, 3, 4}, Subsequence(2, Gt(2))) // succeeds
+//   CmpDeeply(t, []int{1, 2, 3, 4}, Subsequence(3, 1))     // fails
+//
+// In case of failure, the first expected item that could not be
+// placed after the previous ones is reported.
+func Subsequence(expectedItems ...interface{}) TestDeep {
+	return &tdSequence{
+		tdList: newList(expectedItems...),
+		kind:   subSequence,
+	}
+}
+
+func (s *tdSequence) Match(ctx Context, got reflect.Value) (err *Error) {
+	got, err = getArrayOrSlice(ctx, got, s)
+	if err != nil {
+		return err
+	}
+
+	gotLen := got.Len()
+
+	switch s.kind {
+	case prefixSequence:
+		for idx, expected := range s.items {
+			if idx >= gotLen {
+				return s.outOfRange(ctx.AddArrayIndex(idx), expected)
+			}
+
+			err = deepValueEqual(ctx.AddArrayIndex(idx), got.Index(idx), expected)
+			if err != nil {
+				return err.SetLocationIfMissing(s)
+			}
+		}
+
+	case suffixSequence:
+		offset := gotLen - len(s.items)
+		for idx, expected := range s.items {
+			if offset+idx < 0 {
+				// Counted from the end, as it does not exist
+				return s.outOfRange(ctx.AddArrayIndex(idx-len(s.items)), expected)
+			}
+
+			err = deepValueEqual(ctx.AddArrayIndex(offset+idx),
+				got.Index(offset+idx), expected)
+			if err != nil {
+				return err.SetLocationIfMissing(s)
+			}
+		}
+
+	default: // subSequence
+		gotIdx := 0
+		for idx, expected := range s.items {
+			start := gotIdx
+			for gotIdx < gotLen &&
+				deepValueEqual(ctx.boolean(), got.Index(gotIdx), expected) != nil {
+				gotIdx++
+			}
+			if gotIdx < gotLen {
+				gotIdx++
+				continue
+			}
+
+			if ctx.booleanError {
+				return booleanError
+			}
+
+			message := fmt.Sprintf("expected item #%d not found in sequence", idx)
+			if idx > 0 {
+				// Previous expected items are placed before start
+				message += fmt.Sprintf(" from index %d", start)
+			}
+			err = &Error{
+				Context:  ctx,
+				Message:  message,
+				Got:      got,
+				Expected: expected,
+				Location: s.GetLocation(),
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *tdSequence) outOfRange(ctx Context, expected reflect.Value) *Error {
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "expected value out of range",
+		Got:      rawString("<non-existent value>"),
+		Expected: expected,
+		Location: s.GetLocation(),
+	}
+}
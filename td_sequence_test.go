@@ -0,0 +1,157 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestSequence(t *testing.T) {
+	type MyArray [5]int
+	type MySlice []int
+
+	for idx, got := range []interface{}{
+		[]int{1, 2, 3, 4, 5},
+		[...]int{1, 2, 3, 4, 5},
+		MySlice{1, 2, 3, 4, 5},
+		MyArray{1, 2, 3, 4, 5},
+		&MySlice{1, 2, 3, 4, 5},
+		&MyArray{1, 2, 3, 4, 5},
+	} {
+		testName := fmt.Sprintf("Test #%d → %v", idx, got)
+
+		//
+		// SliceHasPrefix
+		checkOK(t, got, SliceHasPrefix(), testName)
+		checkOK(t, got, SliceHasPrefix(1, 2), testName)
+		checkOK(t, got, SliceHasPrefix(1, Gt(1), 3, 4, 5), testName)
+
+		checkError(t, got, SliceHasPrefix(1, 3),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA[1]"),
+				Got:      mustBe("(int) 2"),
+				Expected: mustBe("(int) 3"),
+			},
+			testName)
+
+		checkError(t, got, SliceHasPrefix(1, 2, 3, 4, 5, 6),
+			expectedError{
+				Message:  mustBe("expected value out of range"),
+				Path:     mustBe("DATA[5]"),
+				Got:      mustBe("<non-existent value>"),
+				Expected: mustBe("(int) 6"),
+			},
+			testName)
+
+		//
+		// SliceHasSuffix
+		checkOK(t, got, SliceHasSuffix(), testName)
+		checkOK(t, got, SliceHasSuffix(4, 5), testName)
+		checkOK(t, got, SliceHasSuffix(1, 2, 3, Lt(5), 5), testName)
+
+		checkError(t, got, SliceHasSuffix(3, 5),
+			expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA[3]"),
+				Got:      mustBe("(int) 4"),
+				Expected: mustBe("(int) 3"),
+			},
+			testName)
+
+		checkError(t, got, SliceHasSuffix(0, 1, 2, 3, 4, 5),
+			expectedError{
+				Message:  mustBe("expected value out of range"),
+				Path:     mustBe("DATA[-6]"),
+				Got:      mustBe("<non-existent value>"),
+				Expected: mustBe("(int) 0"),
+			},
+			testName)
+
+		//
+		// Subsequence
+		checkOK(t, got, Subsequence(), testName)
+		checkOK(t, got, Subsequence(1, 3, 5), testName)
+		checkOK(t, got, Subsequence(2, Gt(2), 5), testName)
+		checkOK(t, got, Subsequence(1, 2, 3, 4, 5), testName)
+
+		checkError(t, got, Subsequence(6),
+			expectedError{
+				Message:  mustBe("expected item #0 not found in sequence"),
+				Path:     mustBe("DATA"),
+				Got:      mustContain("5"),
+				Expected: mustBe("(int) 6"),
+			},
+			testName)
+
+		checkError(t, got, Subsequence(2, 4, 3),
+			expectedError{
+				Message:  mustBe("expected item #2 not found in sequence from index 4"),
+				Path:     mustBe("DATA"),
+				Got:      mustContain("5"),
+				Expected: mustBe("(int) 3"),
+			},
+			testName)
+	}
+
+	checkOK(t, []interface{}{"a", nil, 12}, SliceHasPrefix("a", nil))
+	checkOK(t, []interface{}{"a", nil, 12}, Subsequence(nil, 12))
+
+	checkError(t, []int{1}, Subsequence(1, 1),
+		expectedError{
+			Message:  mustBe("expected item #1 not found in sequence from index 1"),
+			Path:     mustBe("DATA"),
+			Expected: mustBe("(int) 1"),
+		})
+
+	//
+	// Bad types
+	var nilSlice *MySlice
+	for _, seq := range []TestDeep{
+		SliceHasPrefix(1), SliceHasSuffix(1), Subsequence(1),
+	} {
+		checkError(t, nilSlice, seq,
+			expectedError{
+				Message:  mustBe("nil pointer"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil *testdeep_test.MySlice"),
+				Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+			})
+
+		checkError(t, nil, seq,
+			expectedError{
+				Message:  mustBe("nil value"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("nil"),
+				Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+			})
+
+		checkError(t, map[int]int{}, seq,
+			expectedError{
+				Message:  mustBe("bad type"),
+				Path:     mustBe("DATA"),
+				Got:      mustBe("map[int]int"),
+				Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+			})
+	}
+
+	//
+	// String
+	equalStr(t, SliceHasPrefix(1, 2).String(),
+		"SliceHasPrefix((int) 1,\n               (int) 2)")
+	equalStr(t, SliceHasSuffix(1).String(), "SliceHasSuffix((int) 1)")
+	equalStr(t, Subsequence().String(), "Subsequence()")
+}
+
+func TestSequenceTypeBehind(t *testing.T) {
+	equalTypes(t, SliceHasPrefix(6), nil)
+	equalTypes(t, SliceHasSuffix(6), nil)
+	equalTypes(t, Subsequence(6), nil)
+}
@@ -38,10 +38,33 @@ func newSetBase(kind setKind, ignoreDups bool) tdSetBase {
 
 func (s *tdSetBase) Add(items ...interface{}) {
 	for _, item := range items {
+		if _, ok := item.(*tdTimes); ok && (s.ignoreDups || s.kind == noneSet) {
+			panic(s.GetLocation().Func +
+				": Times can only be used in Bag, SubBagOf or SuperBagOf")
+		}
 		s.expectedItems = append(s.expectedItems, reflect.ValueOf(item))
 	}
 }
 
+// consume marks as found the got items not already found and matching
+// "expected", but at most "max" of them (or all if "max" is < 0). It
+// returns their indexes.
+func (s *tdSetBase) consume(ctx Context, got, expected reflect.Value, max int,
+	foundGotIdxes map[int]bool) (idxes []int) {
+	gotLen := got.Len()
+	for idx := 0; len(idxes) != max && len(foundGotIdxes) < gotLen && idx < gotLen; idx++ {
+		if foundGotIdxes[idx] {
+			continue
+		}
+
+		if deepValueEqual(ctx.boolean(), got.Index(idx), expected) == nil {
+			foundGotIdxes[idx] = true
+			idxes = append(idxes, idx)
+		}
+	}
+	return
+}
+
 func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 	switch got.Kind() {
 	case reflect.Ptr:
@@ -74,31 +97,53 @@ func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 			foundGotIdxes = map[int]bool{}
 		)
 
-		for _, expected := range s.expectedItems {
-			found := false
+		// Times items with a count operator are handled at the end
+		var deferred []*tdTimes
 
-			for idx := 0; len(foundGotIdxes) < gotLen && idx < gotLen; idx++ {
-				if foundGotIdxes[idx] {
+		for _, expected := range s.expectedItems {
+			if expected.IsValid() && expected.Type() == timesType {
+				times := expected.Interface().(*tdTimes)
+				count, ok := times.fixedCount()
+				if !ok {
+					deferred = append(deferred, times)
 					continue
 				}
 
-				if deepValueEqualOK(got.Index(idx), expected) {
-					foundItems = append(foundItems, expected)
-
-					foundGotIdxes[idx] = true
-					found = true
-
-					if !s.ignoreDups {
-						break
-					}
+				found := len(s.consume(ctx, got, times.item, count, foundGotIdxes))
+				if found < count {
+					missingItems = append(missingItems,
+						newSetItem(times.item, count-found))
 				}
+				continue
 			}
 
-			if !found {
+			max := 1
+			if s.ignoreDups {
+				max = -1
+			}
+
+			if len(s.consume(ctx, got, expected, max, foundGotIdxes)) > 0 {
+				foundItems = append(foundItems, expected)
+			} else {
 				missingItems = append(missingItems, expected)
 			}
 		}
 
+		for _, times := range deferred {
+			idxes := s.consume(ctx, got, times.item, -1, foundGotIdxes)
+
+			count := times.bestCount(ctx, len(idxes))
+			if count < 0 {
+				missingItems = append(missingItems, reflect.ValueOf(times))
+				count = 0
+			}
+
+			// Release the items not needed by times
+			for _, idx := range idxes[count:] {
+				delete(foundGotIdxes, idx)
+			}
+		}
+
 		res := tdSetResult{
 			Kind: itemsSetResult,
 		}
@@ -113,7 +158,7 @@ func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 				nextExpected:
 					for _, expected := range missingItems {
 						for idxGot := range foundGotIdxes {
-							if deepValueEqualOK(got.Index(idxGot), expected) {
+							if deepValueEqual(ctx.boolean(), got.Index(idxGot), expected) == nil {
 								continue nextExpected
 							}
 						}
@@ -128,7 +173,7 @@ func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 					if ctx.booleanError {
 						return booleanError
 					}
-					res.Missing = missingItems
+					res.Missing = groupSetItems(missingItems)
 				}
 			}
 
@@ -144,6 +189,7 @@ func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 						notFoundRemain--
 					}
 				}
+				res.Extra = groupSetItems(res.Extra)
 			}
 		} else if len(foundItems) > 0 {
 			if ctx.booleanError {
@@ -184,6 +230,18 @@ func (s *tdSetBase) Match(ctx Context, got reflect.Value) *Error {
 }
 
 func (s *tdSetBase) String() string {
-	return sliceToBuffer(
-		bytes.NewBufferString(s.GetLocation().Func), s.expectedItems).String()
+	return s.stringToBuffer(&bytes.Buffer{}).String()
+}
+
+func (s *tdSetBase) stringToBuffer(buf *bytes.Buffer) *bytes.Buffer {
+	buf.WriteString(s.GetLocation().Func)
+	return sliceToBuffer(buf, s.expectedItems)
+}
+
+func (s *tdSetBase) treeChildren() []treeChild {
+	children := make([]treeChild, len(s.expectedItems))
+	for idx, item := range s.expectedItems {
+		children[idx].value = item
+	}
+	return children
 }
@@ -9,6 +9,8 @@ package testdeep
 import (
 	"bytes"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 type tdSetResultKind uint8
@@ -16,6 +18,7 @@ type tdSetResultKind uint8
 const (
 	itemsSetResult tdSetResultKind = iota
 	keysSetResult
+	fieldsSetResult
 )
 
 // Implements fmt.Stringer.
@@ -25,6 +28,8 @@ func (k tdSetResultKind) String() string {
 		return "items"
 	case keysSetResult:
 		return "keys"
+	case fieldsSetResult:
+		return "fields"
 	default:
 		return "?"
 	}
@@ -33,25 +38,114 @@ func (k tdSetResultKind) String() string {
 type tdSetResult struct {
 	Missing []reflect.Value
 	Extra   []reflect.Value
-	Kind    tdSetResultKind
+	// TestDeep operators matching no items (or keys)
+	Unmatched []reflect.Value
+	// Items paired by key, but differing
+	Differing []tdSetDiff
+	Kind      tdSetResultKind
 }
 
-var _ testDeepStringer = tdSetResult{}
+// tdSetDiff is a difference between a got item and an expected one
+// sharing the same key.
+type tdSetDiff struct {
+	Key reflect.Value
+	Err *Error
+}
+
+// tdSetCount is an item repeated Count times in a tdSetResult.
+type tdSetCount struct {
+	Item  reflect.Value
+	Count int
+}
+
+var (
+	_ testDeepStringer   = tdSetCount{}
+	_ formattersStringer = tdSetCount{}
+)
+
+var setCountType = reflect.TypeOf(tdSetCount{})
+
+func (c tdSetCount) _TestDeep() {}
+
+func (c tdSetCount) String() string {
+	return c.stringWith(nil)
+}
+
+func (c tdSetCount) stringWith(formatters formatterSet) string {
+	return toStringWith(formatters, c.Item) + " ×" + strconv.Itoa(c.Count)
+}
+
+// newSetItem returns "item" if "count" is 1, or "item" and its count
+// otherwise.
+func newSetItem(item reflect.Value, count int) reflect.Value {
+	if count == 1 {
+		return item
+	}
+	return reflect.ValueOf(tdSetCount{Item: item, Count: count})
+}
+
+// groupSetItems returns "items" where equal items are grouped and
+// counted, so they are not repeated in a tdSetResult. Only items of
+// bool, integer and string kinds are grouped, through a map so it
+// stays linear whatever the number of items is. TestDeep operators
+// are never grouped.
+func groupSetItems(items []reflect.Value) []reflect.Value {
+	if len(items) < 2 {
+		return items
+	}
+
+	var groups map[scalarKey]int // index in grouped
+	grouped := make([]reflect.Value, 0, len(items))
+	counts := make([]int, 0, len(items))
+
+	for _, item := range items {
+		if key, ok := newScalarKey(item); ok {
+			if idx, found := groups[key]; found {
+				counts[idx]++
+				continue
+			}
+			if groups == nil {
+				groups = map[scalarKey]int{}
+			}
+			groups[key] = len(grouped)
+		}
+		grouped = append(grouped, item)
+		counts = append(counts, 1)
+	}
+
+	if len(grouped) == len(items) {
+		return items
+	}
+	for idx, item := range grouped {
+		grouped[idx] = newSetItem(item, counts[idx])
+	}
+	return grouped
+}
+
+var (
+	_ testDeepStringer   = tdSetResult{}
+	_ formattersStringer = tdSetResult{}
+)
 
 func (r tdSetResult) _TestDeep() {}
 
 func (r tdSetResult) IsEmpty() bool {
-	return len(r.Missing) == 0 && len(r.Extra) == 0
+	return len(r.Missing) == 0 && len(r.Extra) == 0 &&
+		len(r.Unmatched) == 0 && len(r.Differing) == 0
 }
 
 func (r tdSetResult) String() string {
+	return r.stringWith(nil)
+}
+
+func (r tdSetResult) stringWith(formatters formatterSet) string {
 	buf := &bytes.Buffer{}
 
 	if len(r.Missing) > 0 {
 		buf.WriteString("Missing ")
 		buf.WriteString(r.Kind.String())
 		buf.WriteString(": ")
-		sliceToBuffer(buf, r.Missing)
+		sliceToBufferWith(formatters, buf, r.Missing)
 	}
 
 	if len(r.Extra) > 0 {
@@ -61,7 +155,38 @@ func (r tdSetResult) String() string {
 		buf.WriteString("Extra ")
 		buf.WriteString(r.Kind.String())
 		buf.WriteString(": ")
-		sliceToBuffer(buf, r.Extra)
+		sliceToBufferWith(formatters, buf, r.Extra)
+	}
+
+	if len(r.Unmatched) > 0 {
+		if buf.Len() > 0 {
+			buf.WriteString("\n  ")
+		}
+		buf.WriteString("Operators matching no ")
+		buf.WriteString(r.Kind.String())
+		buf.WriteString(": ")
+		sliceToBufferWith(formatters, buf, r.Unmatched)
+	}
+
+	if len(r.Differing) > 0 {
+		indent := 0
+		if buf.Len() > 0 {
+			buf.WriteString("\n  ")
+			indent = 2
+		}
+		prefix := "Differing " + r.Kind.String() + ": "
+		buf.WriteString(prefix)
+		indent += len(prefix)
+
+		for idx, diff := range r.Differing {
+			if idx > 0 {
+				buf.WriteByte('\n')
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+			buf.WriteString(toStringWith(formatters, diff.Key))
+			buf.WriteString(" → ")
+			buf.WriteString(diff.Err.compactString(formatters))
+		}
 	}
 
 	return buf.String()
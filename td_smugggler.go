@@ -27,3 +27,10 @@ func newSmuggler(val interface{}) (ret tdSmuggler) {
 	}
 	return
 }
+
+func (s *tdSmuggler) treeChildren() []treeChild {
+	if !s.isTestDeeper {
+		return nil
+	}
+	return []treeChild{{value: s.expectedValue}}
+}
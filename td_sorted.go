@@ -0,0 +1,300 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type tdSorted struct {
+	BaseOKNil
+	keys []sortKey
+}
+
+var _ TestDeep = &tdSorted{}
+
+// sortKey is a Sorted criterion.
+type sortKey struct {
+	field string        // struct field name, empty for the item itself
+	less  reflect.Value // less function, invalid if none
+	desc  bool
+}
+
+// Sorted operator checks that an array or a slice (or a pointer on
+// array/slice) is sorted. Each item of "how" is a criterion, the
+// following ones being used only when items are equal according to
+// the previous ones:
+//   - an int, 1 for ascending order, -1 for descending order of the
+//     items themselves;
+//   - a string, the name of the struct field (of items or of the
+//     structs they point to) compared in ascending order, or in
+//     descending order if the name is prefixed by "-";
+//   - a func(a, b T) bool function, returning true if "a" has to be
+//     placed before "b".
+//
+// Without "how", items have to be in ascending order. Items (or
+// fields) compared without a function have to be of the same type,
+// an integer, a float or a string kind, a time.Time (or convertible)
+// or a type having an order method, see Between for details.
+//
+//   CmpDeeply(t, []int{1, 1, 2}, Sorted())      // succeeds
+//   CmpDeeply(t, []int{3, 2, 1}, Sorted(-1))    // succeeds
+//   CmpDeeply(t, []int{1, 3, 2}, Sorted())      // fails
+//   CmpDeeply(t, users, Sorted("Name", "-Age")) // by name, then oldest first
+//   CmpDeeply(t, []string{"b", "aa"}, Sorted(func(a, b string) bool {
+//       return len(a) < len(b)
+//     })) // succeeds
+//
+// In case of failure, the first item out of order is reported.
+func Sorted(how ...interface{}) TestDeep {
+	s := tdSorted{
+		BaseOKNil: NewBaseOKNil(3),
+	}
+
+	const usage = "usage: Sorted([ORDER|FIELD|LESS_FUNC, ...])"
+
+	for _, criterion := range how {
+		var key sortKey
+
+		switch criterion := criterion.(type) {
+		case int:
+			if criterion == 0 {
+				panic(usage)
+			}
+			key.desc = criterion < 0
+
+		case string:
+			if strings.HasPrefix(criterion, "-") {
+				key.desc = true
+				criterion = criterion[1:]
+			}
+			if criterion == "" {
+				panic(usage)
+			}
+			key.field = criterion
+
+		default:
+			vfn := reflect.ValueOf(criterion)
+			if vfn.Kind() != reflect.Func {
+				panic(usage)
+			}
+
+			fnType := vfn.Type()
+			if fnType.NumIn() != 2 || fnType.In(0) != fnType.In(1) ||
+				fnType.NumOut() != 1 || fnType.Out(0).Kind() != reflect.Bool {
+				panic("Sorted(LESS_FUNC): LESS_FUNC must be func(T, T) bool")
+			}
+			key.less = vfn
+		}
+
+		s.keys = append(s.keys, key)
+	}
+
+	if len(s.keys) == 0 {
+		s.keys = []sortKey{{}}
+	}
+	return &s
+}
+
+func (s *tdSorted) Match(ctx Context, got reflect.Value) (err *Error) {
+	got, err = getArrayOrSlice(ctx, got, s)
+	if err != nil {
+		return err
+	}
+
+	gotLen := got.Len()
+	for idx := 1; idx < gotLen; idx++ {
+		cmp, err := s.compare(ctx, got, idx-1, idx)
+		if err != nil {
+			return err
+		}
+
+		if cmp > 0 {
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  ctx.AddArrayIndex(idx),
+				Message:  fmt.Sprintf("item out of order, compared to item #%d", idx-1),
+				Got:      got.Index(idx),
+				Expected: rawString("not before " + toString(got.Index(idx-1))),
+				Location: s.GetLocation(),
+			}
+		}
+	}
+	return nil
+}
+
+// compare compares items "i" and "j" of "got" according to s
+// criteria, returning -1, 0 or 1.
+func (s *tdSorted) compare(ctx Context, got reflect.Value, i, j int) (int, *Error) {
+	for _, key := range s.keys {
+		a, b := got.Index(i), got.Index(j)
+
+		if key.less.IsValid() {
+			argType := key.less.Type().In(0)
+			for _, idx := range []int{i, j} {
+				if _, ok := funcArg(got.Index(idx), argType); !ok {
+					return 0, s.incompatibleItem(ctx.AddArrayIndex(idx), got.Index(idx), argType)
+				}
+			}
+			if !got.CanInterface() {
+				return 0, s.unexported(ctx)
+			}
+
+			if !ctx.userCodeAllowed() {
+				return 0, booleanError
+			}
+			a, _ = funcArg(a, argType)
+			b, _ = funcArg(b, argType)
+			if key.less.Call([]reflect.Value{a, b})[0].Bool() {
+				return -1, nil
+			}
+			if key.less.Call([]reflect.Value{b, a})[0].Bool() {
+				return 1, nil
+			}
+			continue
+		}
+
+		var err *Error
+		if a, err = itemField(ctx.AddArrayIndex(i), a, key.field, s); err != nil {
+			return 0, err
+		}
+		if b, err = itemField(ctx.AddArrayIndex(j), b, key.field, s); err != nil {
+			return 0, err
+		}
+
+		// Items order method, if any, is user code
+		if a.Type() == b.Type() && orderMethodOf(a.Type()) != nil &&
+			!ctx.userCodeAllowed() {
+			return 0, booleanError
+		}
+
+		cmp, ok := compareOrdered(a, b)
+		if !ok {
+			if ctx.booleanError {
+				return 0, booleanError
+			}
+			err = &Error{
+				Context:  ctx.AddArrayIndex(j),
+				Location: s.GetLocation(),
+			}
+			if a.Type() != b.Type() {
+				err.Message = "type mismatch"
+				err.Got = rawString(b.Type().String())
+				err.Expected = rawString(a.Type().String())
+			} else {
+				err.Message = "cannot order items"
+				err.Got = rawString(b.Type().String())
+				err.Expected = rawString("integer, float, string or time.Time")
+			}
+			return 0, err
+		}
+
+		if cmp != 0 {
+			if key.desc {
+				return -cmp, nil
+			}
+			return cmp, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *tdSorted) incompatibleItem(ctx Context, item reflect.Value, argType reflect.Type) *Error {
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "incompatible parameter type",
+		Got:      rawString(item.Type().String()),
+		Expected: rawString(argType.String()),
+		Location: s.GetLocation(),
+	}
+}
+
+func (s *tdSorted) unexported(ctx Context) *Error {
+	if ctx.booleanError {
+		return booleanError
+	}
+	// Refuse to override unexported fields access, as Code does
+	return &Error{
+		Context:  ctx,
+		Message:  "cannot compare unexported field",
+		Summary:  rawString("use Code() on surrounding struct instead"),
+		Location: s.GetLocation(),
+	}
+}
+
+func (s *tdSorted) String() string {
+	buf := bytes.NewBufferString("Sorted(")
+	for idx, key := range s.keys {
+		if idx > 0 {
+			buf.WriteString(", ")
+		}
+		switch {
+		case key.less.IsValid():
+			buf.WriteString(key.less.Type().String())
+		case key.field != "":
+			fmt.Fprintf(buf, "%q", // nolint: errcheck
+				ternStr(key.desc, "-", "")+key.field)
+		default:
+			buf.WriteString(ternStr(key.desc, "-1", "1"))
+		}
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// itemField returns "item", interfaces being followed, if "field" is
+// empty. Otherwise it returns the value of "item" struct field named
+// "field", pointers being followed too.
+func itemField(ctx Context, item reflect.Value, field string, td TestDeep) (reflect.Value, *Error) {
+	for item.Kind() == reflect.Interface ||
+		(field != "" && item.Kind() == reflect.Ptr) {
+		if item.IsNil() {
+			if ctx.booleanError {
+				return item, booleanError
+			}
+			return item, &Error{
+				Context:  ctx,
+				Message:  "nil " + item.Kind().String(),
+				Got:      rawString("nil"),
+				Expected: rawString(ternStr(field == "", "non-nil value", "struct with field "+field)),
+				Location: td.GetLocation(),
+			}
+		}
+		item = item.Elem()
+	}
+
+	if field == "" {
+		return item, nil
+	}
+
+	if item.Kind() == reflect.Struct {
+		if sf, ok := getTypeInfo(item.Type()).fieldByName(field); ok {
+			if fieldValue, ok := fieldByIndex(item, sf.index); ok {
+				return fieldValue, nil
+			}
+		}
+	}
+
+	if ctx.booleanError {
+		return item, booleanError
+	}
+	return item, &Error{
+		Context:  ctx,
+		Message:  "field not found",
+		Got:      rawString(item.Type().String()),
+		Expected: rawString("struct with field " + field),
+		Location: td.GetLocation(),
+	}
+}
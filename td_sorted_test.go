@@ -0,0 +1,178 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+type sortedPerson struct {
+	Name string
+	Age  int
+}
+
+func TestSorted(t *testing.T) {
+	type MySlice []int
+
+	checkOK(t, []int{}, Sorted())
+	checkOK(t, []int{1}, Sorted())
+	checkOK(t, []int{1, 1, 2, 3}, Sorted())
+	checkOK(t, [4]int{1, 1, 2, 3}, Sorted(1))
+	checkOK(t, &MySlice{3, 2, 2, 1}, Sorted(-1))
+	checkOK(t, []string{"a", "b", "b"}, Sorted())
+	checkOK(t, []float64{-1.5, 0, 3.25}, Sorted())
+	checkOK(t, []uint8{1, 2, 3}, Sorted())
+	checkOK(t, []interface{}{1, 2, 3}, Sorted())
+
+	now := time.Now()
+	checkOK(t, []time.Time{now.Add(-time.Hour), now, now.Add(time.Hour)}, Sorted())
+
+	checkError(t, []int{1, 3, 2}, Sorted(),
+		expectedError{
+			Message:  mustBe("item out of order, compared to item #1"),
+			Path:     mustBe("DATA[2]"),
+			Got:      mustBe("(int) 2"),
+			Expected: mustBe("not before (int) 3"),
+		})
+	checkError(t, []int{1, 2}, Sorted(-1),
+		expectedError{
+			Message:  mustBe("item out of order, compared to item #0"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("(int) 2"),
+			Expected: mustBe("not before (int) 1"),
+		})
+
+	//
+	// Fields
+	persons := []sortedPerson{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 42},
+		{Name: "Bob", Age: 20},
+	}
+	checkOK(t, persons, Sorted("Name"))
+	checkOK(t, persons, Sorted("Name", "-Age"))
+	checkOK(t, []*sortedPerson{&persons[0], &persons[1]}, Sorted("Age"))
+
+	checkError(t, persons, Sorted("Name", "Age"),
+		expectedError{
+			Message: mustBe("item out of order, compared to item #1"),
+			Path:    mustBe("DATA[2]"),
+		})
+	checkError(t, persons, Sorted("Age"),
+		expectedError{
+			Message: mustBe("item out of order, compared to item #1"),
+			Path:    mustBe("DATA[2]"),
+		})
+	checkError(t, persons, Sorted("Unknown"),
+		expectedError{
+			Message:  mustBe("field not found"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("testdeep_test.sortedPerson"),
+			Expected: mustBe("struct with field Unknown"),
+		})
+	checkError(t, []*sortedPerson{&persons[0], nil}, Sorted("Age"),
+		expectedError{
+			Message:  mustBe("nil ptr"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("struct with field Age"),
+		})
+
+	// Order method takes precedence over string kind
+	checkOK(t, []betweenSemver{"1.2", "1.9", "1.10"}, Sorted())
+
+	// Order method is user code, so called only during the full pass
+	// of CmpDeeply
+	betweenCompareCalls = 0
+	EqDeeply([]betweenCounted{1, 3, 2}, Sorted()) // single pass
+	singlePass := betweenCompareCalls
+	betweenCompareCalls = 0
+	if CmpDeeply(&testing.T{}, []betweenCounted{1, 3, 2}, Sorted()) {
+		t.Error("CmpDeeply should fail")
+	}
+	if betweenCompareCalls != singlePass {
+		t.Errorf("Compare method called %d times instead of %d",
+			betweenCompareCalls, singlePass)
+	}
+
+	//
+	// Less function
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+	checkOK(t, []string{"c", "ab", "xyz"}, Sorted(byLen))
+	checkOK(t, []string{"c", "a", "xyz"}, Sorted(byLen)) // equal lengths
+	checkOK(t, []string{"c", "a", "xy", "ab"}, Sorted(byLen, -1))
+	checkOK(t, []interface{}{"a", "bc"}, Sorted(byLen))
+
+	checkError(t, []string{"ab", "c"}, Sorted(byLen),
+		expectedError{
+			Message:  mustBe("item out of order, compared to item #0"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe(`(string) (len=1) "c"`),
+			Expected: mustBe(`not before (string) (len=2) "ab"`),
+		})
+	checkError(t, []int{1, 2}, Sorted(byLen),
+		expectedError{
+			Message:  mustBe("incompatible parameter type"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("int"),
+			Expected: mustBe("string"),
+		})
+
+	//
+	// Bad types
+	checkError(t, []interface{}{1, "a"}, Sorted(),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("string"),
+			Expected: mustBe("int"),
+		})
+	checkError(t, []bool{true, false}, Sorted(),
+		expectedError{
+			Message:  mustBe("cannot order items"),
+			Path:     mustBe("DATA[1]"),
+			Got:      mustBe("bool"),
+			Expected: mustBe("integer, float, string or time.Time"),
+		})
+	checkError(t, map[int]bool{}, Sorted(),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("map[int]bool"),
+			Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+		})
+	checkError(t, nil, Sorted(),
+		expectedError{
+			Message:  mustBe("nil value"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Sorted(0) }, "usage: Sorted(")
+	checkPanic(t, func() { Sorted("-") }, "usage: Sorted(")
+	checkPanic(t, func() { Sorted(12.3) }, "usage: Sorted(")
+	checkPanic(t, func() { Sorted(func(a string, b int) bool { return true }) },
+		"LESS_FUNC must be func(T, T) bool")
+	checkPanic(t, func() { Sorted(func(a, b int) int { return 0 }) },
+		"LESS_FUNC must be func(T, T) bool")
+
+	//
+	// String
+	equalStr(t, Sorted().String(), "Sorted(1)")
+	equalStr(t, Sorted("Name", "-Age", -1).String(), `Sorted("Name", "-Age", -1)`)
+	equalStr(t, Sorted(byLen).String(), "Sorted(func(string, string) bool)")
+}
+
+func TestSortedTypeBehind(t *testing.T) {
+	equalTypes(t, Sorted(), nil)
+}
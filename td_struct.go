@@ -10,8 +10,11 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 )
 
 type tdStruct struct {
@@ -19,6 +22,7 @@ type tdStruct struct {
 	expectedModel  reflect.Value
 	expectedFields fieldInfoSlice
 	isPtr          bool
+	strict         bool
 }
 
 var _ TestDeep = &tdStruct{}
@@ -26,7 +30,17 @@ var _ TestDeep = &tdStruct{}
 type fieldInfo struct {
 	name     string
 	expected reflect.Value
-	index    []int
+	// names & indexes of the fields to traverse to reach this field,
+	// more than one for nested fields (as in "A.B.C")
+	names   []string
+	indexes [][]int
+	// the reached field, the last of names
+	field structField
+	// true if zero in model and absent from StructFields (SStruct only)
+	unchecked bool
+	// true if listed in StructFields, so checked even if matched by
+	// an ignore rule of ContextConfig
+	explicit bool
 }
 
 type fieldInfoSlice []fieldInfo
@@ -39,13 +53,30 @@ func (e fieldInfoSlice) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
 // Struct. It is a map whose each key is the expected field name and
 // the corresponding value the expected field value (which can be a
 // TestDeep operator as well as a zero value.)
+//
+// A key can also be:
+//   - a dotted path as "Owner.Address.City" to reach a field of a
+//     nested struct, pointers on structs being traversed. The other
+//     fields of "Owner" and "Address" are still checked against
+//     "model", as if they were listed one by one;
+//   - a regexp prefixed by "=~" as "=~^Created", or a glob pattern as
+//     "Meta*" (see path.Match), whose value is then expected for all
+//     the matching fields not explicitly listed in StructFields.
+//
+// Fields listed in StructFields, by their name as well as by a
+// pattern, are checked even if ContextConfig ignore rules (as
+// IgnoreFields) match them.
+//
+// If a field name is unknown, the panic message suggests the closest
+// existing one.
 type StructFields map[string]interface{}
 
-func newStruct(model interface{}) *tdStruct {
+func newStruct(model interface{}, strict bool) *tdStruct {
 	vmodel := reflect.ValueOf(model)
 
 	st := tdStruct{
-		Base: NewBase(4),
+		Base:   NewBase(4),
+		strict: strict,
 	}
 
 	switch vmodel.Kind() {
@@ -62,7 +93,7 @@ func newStruct(model interface{}) *tdStruct {
 		return &st
 	}
 
-	panic("usage: Struct(STRUCT|&STRUCT, EXPECTED_FIELDS)")
+	panic("usage: " + st.opName() + "(STRUCT|&STRUCT, EXPECTED_FIELDS)")
 }
 
 // Struct operator compares the contents of a struct or a pointer on a
@@ -79,77 +110,167 @@ func newStruct(model interface{}) *tdStruct {
 //
 // TypeBehind method returns the reflect.Type of "model".
 func Struct(model interface{}, expectedFields StructFields) TestDeep {
-	st := newStruct(model)
+	st := newStruct(model, false)
+	st.populateExpectedFields(expectedFields)
+	return st
+}
+
+// SStruct operator (aka strict-Struct) compares the contents of a
+// struct or a pointer on a struct against all the values of "model"
+// and the values of "expectedFields". Contrary to Struct operator,
+// zero fields of "model" are also checked, so each field not
+// present in "expectedFields" has to be equal to the "model" one,
+// zero included.
+//
+// "model" must be the same type as compared data.
+//
+// "expectedFields" can be nil, if no TestDeep operator are involved.
+//
+// During a match, all fields must match to succeed. If a field
+// absent from "expectedFields" and zero in "model" does not match,
+// the error lists all such unchecked fields.
+//
+// TypeBehind method returns the reflect.Type of "model".
+func SStruct(model interface{}, expectedFields StructFields) TestDeep {
+	st := newStruct(model, true)
+	st.populateExpectedFields(expectedFields)
+	return st
+}
 
+func (st *tdStruct) populateExpectedFields(expectedFields StructFields) {
 	st.expectedFields = make([]fieldInfo, 0, len(expectedFields))
 	checkedFields := make(map[string]bool, len(expectedFields))
+	// Fields partially checked by nested keys, as "A" and "A.B" for
+	// "A.B.C"
+	coveredFields := map[string]bool{}
 
 	vmodel := st.expectedModel
 
 	// Check that all given fields are available in model
-	stType := vmodel.Type()
-	var vexpectedValue reflect.Value
+	stInfo := getTypeInfo(vmodel.Type())
+	var patterns []string
 	for fieldName, expectedValue := range expectedFields {
-		field, found := stType.FieldByName(fieldName)
-		if !found {
-			panic(fmt.Sprintf("struct %s has no field `%s'",
-				vmodel.Type(), fieldName))
+		if isFieldPattern(fieldName) {
+			patterns = append(patterns, fieldName)
+			continue
 		}
 
-		if expectedValue == nil {
-			switch field.Type.Kind() {
-			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
-				reflect.Ptr, reflect.Slice:
-				vexpectedValue = reflect.Zero(field.Type) // change to a typed nil
-			default:
-				panic(fmt.Sprintf(
-					"expected value of field %s cannot be nil as it is a %s",
-					fieldName,
-					field.Type))
+		names, indexes, field := resolveFieldPath(vmodel.Type(), fieldName)
+
+		st.expectedFields = append(st.expectedFields, fieldInfo{
+			name:     fieldName,
+			expected: expectedFieldValue(fieldName, field.typ, expectedValue),
+			names:    names,
+			indexes:  indexes,
+			field:    field,
+			explicit: true,
+		})
+		checkedFields[fieldName] = true
+		for idx := 1; idx < len(names); idx++ {
+			coveredFields[strings.Join(names[:idx], ".")] = true
+		}
+	}
+
+	// Apply patterns to fields not explicitly expected
+	if len(patterns) > 0 {
+		sort.Strings(patterns)
+
+		matchers := make([]func(string) bool, len(patterns))
+		for idx, pattern := range patterns {
+			matchers[idx] = newFieldMatcher(pattern)
+		}
+
+		matchedPatterns := map[string]string{}
+		for _, field := range stInfo.visibleFields {
+			if field.anonymous || checkedFields[field.name] {
+				continue
 			}
-		} else {
-			vexpectedValue = reflect.ValueOf(expectedValue)
 
-			if _, ok := expectedValue.(TestDeep); !ok {
-				if !vexpectedValue.Type().AssignableTo(field.Type) {
+			for idx, pattern := range patterns {
+				if !matchers[idx](field.name) {
+					continue
+				}
+
+				if prevPattern, ok := matchedPatterns[field.name]; ok {
 					panic(fmt.Sprintf(
-						"type %s of field expected value %s differs from struct one (%s)",
-						vexpectedValue.Type(),
-						fieldName,
-						field.Type))
+						"field %s is matched by several StructFields patterns: `%s' & `%s'",
+						field.name, prevPattern, pattern))
 				}
+				matchedPatterns[field.name] = pattern
+
+				st.expectedFields = append(st.expectedFields, fieldInfo{
+					name: field.name,
+					expected: expectedFieldValue(
+						field.name, field.typ, expectedFields[pattern]),
+					names:    []string{field.name},
+					indexes:  [][]int{field.index},
+					field:    field,
+					explicit: true,
+				})
 			}
 		}
 
-		st.expectedFields = append(st.expectedFields, fieldInfo{
-			name:     fieldName,
-			expected: vexpectedValue,
-			index:    field.Index,
-		})
-		checkedFields[fieldName] = true
-	}
+		for _, pattern := range patterns {
+			found := false
+			for _, matchedPattern := range matchedPatterns {
+				if matchedPattern == pattern {
+					found = true
+					break
+				}
+			}
+			if !found {
+				panic(fmt.Sprintf("StructFields pattern `%s' matches no field of struct %s",
+					pattern, vmodel.Type()))
+			}
+		}
 
-	// Get all field names
-	var allFields []string
-	stType.FieldByNameFunc(func(fieldName string) bool {
-		allFields = append(allFields, fieldName)
-		return false
-	})
+		for fieldName := range matchedPatterns {
+			checkedFields[fieldName] = true
+		}
+	}
 
 	// Check initialized fields in model
-	for _, fieldName := range allFields {
-		field, _ := stType.FieldByName(fieldName)
-		if field.Anonymous {
+	st.addModelFields(vmodel, nil, nil, checkedFields, coveredFields)
+
+	sort.Sort(st.expectedFields)
+}
+
+// addModelFields adds to st expected fields the fields of "vmodel"
+// struct not listed in StructFields: non-zero ones, as well as zero
+// ones for SStruct. "names" and "indexes" describe how "vmodel" is
+// reached from the model, as in fieldInfo. The fields partially
+// checked by nested StructFields keys are traversed, so their other
+// fields are handled the same way.
+func (st *tdStruct) addModelFields(vmodel reflect.Value,
+	names []string, indexes [][]int, checkedFields, coveredFields map[string]bool) {
+	for _, field := range getTypeInfo(vmodel.Type()).visibleFields {
+		if field.anonymous {
 			continue
 		}
 
-		vfield := vmodel.FieldByIndex(field.Index)
+		fieldNames := append(names[:len(names):len(names)], field.name)
+		fieldIndexes := append(indexes[:len(indexes):len(indexes)], field.index)
+		fieldName := strings.Join(fieldNames, ".")
+
+		vfield := vmodel.FieldByIndex(field.index)
 
 		// Try to force access to unexported fields
 		if !vfield.CanInterface() {
 			vfield = unsafeReflectValue(vfield)
 		}
 
+		if coveredFields[fieldName] {
+			if vfield.Kind() == reflect.Ptr {
+				if vfield.IsNil() {
+					vfield = reflect.New(vfield.Type().Elem())
+				}
+				vfield = vfield.Elem()
+			}
+			st.addModelFields(vfield, fieldNames, fieldIndexes,
+				checkedFields, coveredFields)
+			continue
+		}
+
 		fieldIf, ok := getInterface(vfield, false) // no need to force here
 		if !ok {
 			// Probably in an environment where "unsafe" package is forbidden... :(
@@ -159,7 +280,7 @@ func Struct(model interface{}, expectedFields StructFields) TestDeep {
 		}
 
 		// If non-zero field
-		if !reflect.DeepEqual(reflect.Zero(field.Type).Interface(), fieldIf) {
+		if !reflect.DeepEqual(getTypeInfo(field.typ).zero.Interface(), fieldIf) {
 			if checkedFields[fieldName] {
 				panic(fmt.Sprintf(
 					"non zero field %s in model already exists in expectedFields",
@@ -169,14 +290,119 @@ func Struct(model interface{}, expectedFields StructFields) TestDeep {
 			st.expectedFields = append(st.expectedFields, fieldInfo{
 				name:     fieldName,
 				expected: vfield,
-				index:    field.Index,
+				names:    fieldNames,
+				indexes:  fieldIndexes,
+				field:    field,
+			})
+		} else if st.strict && !checkedFields[fieldName] {
+			st.expectedFields = append(st.expectedFields, fieldInfo{
+				name:      fieldName,
+				expected:  vfield,
+				names:     fieldNames,
+				indexes:   fieldIndexes,
+				field:     field,
+				unchecked: true,
 			})
 		}
 	}
+}
 
-	sort.Sort(st.expectedFields)
+// isFieldPattern returns true if "key" is a StructFields pattern
+// key, so a regexp prefixed by "=~" or a glob pattern.
+func isFieldPattern(key string) bool {
+	return strings.HasPrefix(key, "=~") || strings.ContainsAny(key, "*?[")
+}
 
-	return st
+// newFieldMatcher returns a function reporting whether a field name
+// matches "pattern", "pattern" being a StructFields pattern key.
+func newFieldMatcher(pattern string) func(string) bool {
+	if strings.HasPrefix(pattern, "=~") {
+		re, err := regexp.Compile(pattern[2:])
+		if err != nil {
+			panic(fmt.Sprintf("bad StructFields regexp `%s': %s", pattern, err))
+		}
+		return re.MatchString
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		panic(fmt.Sprintf("bad StructFields pattern `%s': %s", pattern, err))
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	}
+}
+
+// resolveFieldPath returns the names and the indexes of the fields
+// to traverse to reach "fieldPath" field (as in "A.B.C") from "typ"
+// struct type, as well as the reached field. Pointers on structs are
+// traversed as well.
+func resolveFieldPath(typ reflect.Type, fieldPath string) (names []string, indexes [][]int, field structField) {
+	fieldType := typ
+	names = strings.Split(fieldPath, ".")
+	indexes = make([][]int, len(names))
+
+	for idx, name := range names {
+		if idx > 0 {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Struct {
+				panic(fmt.Sprintf(
+					"field %s of struct %s is neither a struct nor a pointer on a struct, so cannot reach `%s'",
+					strings.Join(names[:idx], "."), typ, fieldPath))
+			}
+		}
+
+		info := getTypeInfo(fieldType)
+		stField, found := info.fieldByName(name)
+		if !found {
+			msg := fmt.Sprintf("struct %s has no field `%s'", fieldType, name)
+			if idx > 0 {
+				msg += fmt.Sprintf(" (in `%s')", fieldPath)
+			}
+			if closest := info.closestFieldName(name); closest != "" {
+				msg += fmt.Sprintf(", did you mean `%s'?", closest)
+			}
+			panic(msg)
+		}
+
+		indexes[idx] = stField.index
+		fieldType = stField.typ
+		field = *stField
+	}
+	return
+}
+
+// expectedFieldValue returns "expectedValue" as the expected value of
+// field "fieldName" of type "fieldType", panicking if it is not
+// compatible.
+func expectedFieldValue(fieldName string, fieldType reflect.Type,
+	expectedValue interface{}) reflect.Value {
+	if expectedValue == nil {
+		// change to a typed nil
+		vexpectedValue, ok := nilValue(fieldType)
+		if !ok {
+			panic(fmt.Sprintf(
+				"expected value of field %s cannot be nil as it is a %s",
+				fieldName,
+				fieldType))
+		}
+		return vexpectedValue
+	}
+
+	vexpectedValue := reflect.ValueOf(expectedValue)
+
+	if _, ok := expectedValue.(TestDeep); !ok {
+		if !vexpectedValue.Type().AssignableTo(fieldType) {
+			panic(fmt.Sprintf(
+				"type %s of field expected value %s differs from struct one (%s)",
+				vexpectedValue.Type(),
+				fieldName,
+				fieldType))
+		}
+	}
+	return vexpectedValue
 }
 
 func (s *tdStruct) Match(ctx Context, got reflect.Value) (err *Error) {
@@ -215,18 +441,83 @@ func (s *tdStruct) Match(ctx Context, got reflect.Value) (err *Error) {
 	}
 
 	for _, fieldInfo := range s.expectedFields {
-		err = deepValueEqual(ctx.AddDepth("."+fieldInfo.name),
-			got.FieldByIndex(fieldInfo.index),
-			fieldInfo.expected)
+		gotField, fieldCtx, err := fieldInfo.reach(ctx, got)
+		if err != nil {
+			if ctx.booleanError {
+				return booleanError
+			}
+			err.Location = s.GetLocation()
+			return err
+		}
+
+		if !fieldInfo.explicit {
+			if reason, ignored := ctx.ignoreField(&fieldInfo.field); ignored {
+				fieldCtx.recordIgnored(reason, gotField, fieldInfo.expected)
+				continue
+			}
+		}
+
+		err = deepValueEqual(fieldCtx, gotField, fieldInfo.expected)
 		if err != nil {
+			if fieldInfo.unchecked && !ctx.booleanError {
+				return &Error{
+					Context: ctx,
+					Message: fmt.Sprintf(
+						"field %s is not zero (absent from StructFields and zero in model)",
+						fieldInfo.name),
+					Summary:  rawString("unchecked fields: " + s.uncheckedFieldsStr()),
+					Location: s.GetLocation(),
+					Origin:   err.SetLocationIfMissing(s),
+				}
+			}
 			return err.SetLocationIfMissing(s)
 		}
 	}
 	return nil
 }
 
+// reach returns the field described by f in "got" struct, as well as
+// the corresponding Context. An error is returned if a nil pointer is
+// encountered while reaching a nested field.
+func (f *fieldInfo) reach(ctx Context, got reflect.Value) (reflect.Value, Context, *Error) {
+	for idx, index := range f.indexes {
+		if idx > 0 && got.Kind() == reflect.Ptr {
+			if got.IsNil() {
+				return reflect.Value{}, ctx, &Error{
+					Context:  ctx,
+					Message:  "nil pointer, cannot reach field " + f.name,
+					Got:      rawString("nil"),
+					Expected: rawString("non-nil " + got.Type().String()),
+				}
+			}
+			got = got.Elem()
+		}
+
+		got = got.FieldByIndex(index)
+		ctx = ctx.AddField(f.names[idx])
+	}
+	return got, ctx, nil
+}
+
+// uncheckedFieldsStr returns the comma separated list of the fields
+// checked only because they are zero in model (SStruct only).
+func (s *tdStruct) uncheckedFieldsStr() string {
+	var names []string
+	for _, fieldInfo := range s.expectedFields {
+		if fieldInfo.unchecked {
+			names = append(names, fieldInfo.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (s *tdStruct) opName() string {
+	return ternStr(s.strict, "SStruct", "Struct")
+}
+
 func (s *tdStruct) String() string {
-	buf := bytes.NewBufferString("Struct(")
+	buf := bytes.NewBufferString(s.opName())
+	buf.WriteByte('(')
 
 	if s.isPtr {
 		buf.WriteByte('*')
@@ -234,14 +525,25 @@ func (s *tdStruct) String() string {
 
 	buf.WriteString(s.expectedModel.Type().String())
 
-	if len(s.expectedFields) == 0 {
+	numFields := 0
+	for _, fieldInfo := range s.expectedFields {
+		if !fieldInfo.unchecked {
+			numFields++
+		}
+	}
+
+	if numFields == 0 {
 		buf.WriteString("{})")
 	} else {
 		buf.WriteString("{\n")
 
 		for _, fieldInfo := range s.expectedFields {
-			fmt.Fprintf(buf, "  %s: %s\n", // nolint: errcheck
-				fieldInfo.name, toString(fieldInfo.expected))
+			// Zero fields of SStruct are implicit
+			if fieldInfo.unchecked {
+				continue
+			}
+			fmt.Fprintf(buf, "  %s: ", fieldInfo.name) // nolint: errcheck
+			valueToBuffer(buf, fieldInfo.expected, "  ").WriteByte('\n')
 		}
 
 		buf.WriteString("})")
@@ -250,6 +552,19 @@ func (s *tdStruct) String() string {
 	return buf.String()
 }
 
+func (s *tdStruct) treeChildren() []treeChild {
+	children := make([]treeChild, 0, len(s.expectedFields))
+	for _, fieldInfo := range s.expectedFields {
+		if !fieldInfo.unchecked {
+			children = append(children, treeChild{
+				name:  "." + fieldInfo.name,
+				value: fieldInfo.expected,
+			})
+		}
+	}
+	return children
+}
+
 func (s *tdStruct) TypeBehind() reflect.Type {
 	if s.isPtr {
 		return reflect.New(s.expectedModel.Type()).Type()
@@ -0,0 +1,391 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type tdStructLike struct {
+	Base
+	expected reflect.Value // struct or map with string keys
+	tag      string
+}
+
+var _ TestDeep = &tdStructLike{}
+
+// StructLike operator compares the contents of a struct, a pointer
+// on a struct or a map with string keys against "expected", a struct,
+// a pointer on a struct or a map with string keys too. Contrary to
+// Struct operator, types of compared data and "expected" can differ:
+// fields (or entries) are matched by name, each "expected" one has to
+// be found in compared data and vice versa.
+//
+// If "tag" is passed, fields are named using this struct tag (as
+// "json") instead of their Go name. As for encoding/json, a field
+// whose tag name is "-" is ignored and a field without tag name keeps
+// its Go name.
+//
+//   CmpDeeply(t, userDTO, StructLike(User{Name: "Bob", Age: 42}))
+//   CmpDeeply(t, user, StructLike(map[string]interface{}{
+//     "name": "Bob",
+//     "age":  Between(40, 45),
+//   }, "json"))
+//
+// Nested structs of different types are compared by name as
+// well. Unexported fields are compared as any other field.
+//
+// See also ContextConfig.StructsByName to apply this behavior to
+// all the structs compared by CmpDeeply.
+//
+// TypeBehind method returns nil as any type matching by name is
+// accepted.
+func StructLike(expected interface{}, tag ...string) TestDeep {
+	s := tdStructLike{
+		Base: NewBase(3),
+	}
+
+	const usage = "(STRUCT|&STRUCT|MAP, [TAG])"
+
+	if len(tag) > 1 {
+		panic("usage: StructLike" + usage)
+	}
+	if len(tag) == 1 {
+		s.tag = tag[0]
+	}
+
+	vexpected := reflect.ValueOf(expected)
+	if vexpected.Kind() == reflect.Ptr && !vexpected.IsNil() {
+		vexpected = vexpected.Elem()
+	}
+	if !vexpected.IsValid() || !isByNameType(vexpected.Type()) {
+		panic("usage: StructLike" + usage)
+	}
+	s.expected = vexpected
+
+	return &s
+}
+
+func (s *tdStructLike) Match(ctx Context, got reflect.Value) *Error {
+	ctx.byName, ctx.byNameTag = true, s.tag
+
+	for got.Kind() == reflect.Ptr || got.Kind() == reflect.Interface {
+		if got.IsNil() {
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  ctx,
+				Message:  "nil " + got.Kind().String(),
+				Got:      rawString(got.Type().String() + "(nil)"),
+				Expected: rawString(s.expected.Type().String() + " like"),
+				Location: s.GetLocation(),
+			}
+		}
+		if got.Kind() == reflect.Ptr {
+			ctx = ctx.AddPtr(1)
+		}
+		got = got.Elem()
+	}
+
+	if !isByNameType(got.Type()) {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "type mismatch",
+			Got:      rawString(got.Type().String()),
+			Expected: rawString("struct or map with string keys"),
+			Location: s.GetLocation(),
+		}
+	}
+
+	err := deepValueEqualByName(ctx, got, s.expected, s.tag)
+	if err != nil {
+		return err.SetLocationIfMissing(s)
+	}
+	return nil
+}
+
+func (s *tdStructLike) String() string {
+	buf := bytes.NewBufferString("StructLike(")
+	buf.WriteString(s.expected.Type().String())
+
+	entries := byNameEntries(s.expected, s.tag)
+	if len(entries) == 0 {
+		buf.WriteString("{}")
+	} else {
+		buf.WriteString("{\n")
+		for _, entry := range entries {
+			fmt.Fprintf(buf, "  %s: ", entry.name) // nolint: errcheck
+			valueToBuffer(buf, entry.value, "  ").WriteByte('\n')
+		}
+		buf.WriteByte('}')
+	}
+
+	if s.tag != "" {
+		fmt.Fprintf(buf, ", %q", s.tag) // nolint: errcheck
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+func (s *tdStructLike) treeChildren() []treeChild {
+	entries := byNameEntries(s.expected, s.tag)
+	children := make([]treeChild, len(entries))
+	for idx, entry := range entries {
+		children[idx] = treeChild{
+			name:  "." + entry.name,
+			value: entry.value,
+		}
+	}
+	return children
+}
+
+func (s *tdStructLike) TypeBehind() reflect.Type {
+	return nil
+}
+
+// isByNameType returns true if "typ" can be compared by name, so is
+// a struct or a map with string keys.
+func isByNameType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Map:
+		return typ.Key().Kind() == reflect.String
+	}
+	return false
+}
+
+// canCompareByName returns true if values of "got" and "expected"
+// different types can be compared by name, so if at least one of
+// them is a struct. Pointers are followed if both types are pointers,
+// or if "got" one is a pointer and "expected" one a map.
+func canCompareByName(got, expected reflect.Type) bool {
+	for got.Kind() == reflect.Ptr && expected.Kind() == reflect.Ptr {
+		got, expected = got.Elem(), expected.Elem()
+	}
+	if expected.Kind() == reflect.Map {
+		for got.Kind() == reflect.Ptr {
+			got = got.Elem()
+		}
+	}
+	return isByNameType(got) && isByNameType(expected) &&
+		(got.Kind() == reflect.Struct || expected.Kind() == reflect.Struct)
+}
+
+// byNameEntry is a field of a struct or an entry of a map with
+// string keys, as compared by name.
+type byNameEntry struct {
+	name  string
+	value reflect.Value
+	key   reflect.Value // map key, invalid for a struct field
+	field *structField  // struct field, nil for a map entry
+}
+
+// byNameEntries returns the entries of "v", a struct or a map with
+// string keys, sorted by name. For structs, fields are named using
+// "tag" struct tag if not empty.
+func byNameEntries(v reflect.Value, tag string) []byNameEntry {
+	var entries []byNameEntry
+
+	if v.Kind() == reflect.Map {
+		entries = make([]byNameEntry, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			value := v.MapIndex(key)
+			if value.Kind() == reflect.Interface {
+				value = value.Elem() // invalid if nil
+			}
+			entries = append(entries, byNameEntry{
+				name:  key.String(),
+				value: value,
+				key:   key,
+			})
+		}
+	} else {
+		fields := getTypeInfo(v.Type()).visibleFields
+		entries = make([]byNameEntry, 0, len(fields))
+		for i := range fields {
+			field := &fields[i]
+			if field.anonymous {
+				continue
+			}
+
+			name := field.name
+			if tag != "" {
+				tagName := strings.SplitN(field.tag.Get(tag), ",", 2)[0]
+				if tagName == "-" {
+					continue
+				}
+				if tagName != "" {
+					name = tagName
+				}
+			}
+
+			value, ok := fieldByIndex(v, field.index)
+			if !ok {
+				continue // promoted through a nil embedded pointer
+			}
+
+			entries = append(entries, byNameEntry{
+				name:  name,
+				value: value,
+				field: field,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
+
+// removeIgnored removes from "entries" the struct fields ignored
+// according to ctx config.
+func removeIgnored(ctx Context, entries []byNameEntry) []byNameEntry {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.field != nil {
+			if _, ignored := ctx.ignoreField(entry.field); ignored {
+				continue
+			}
+		}
+		kept = append(kept, entry)
+	}
+	return kept
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but returns false
+// instead of panicking when traversing a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for idx, fieldIdx := range index {
+		if idx > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIdx)
+	}
+	return v, true
+}
+
+// deepValueEqualByName compares "got" and "expected", both structs
+// or maps with string keys (or pointers on them), field by field,
+// fields being matched by name.
+func deepValueEqualByName(ctx Context, got, expected reflect.Value, tag string) *Error {
+	if got.Kind() == reflect.Ptr {
+		if expected.Kind() != reflect.Ptr {
+			if got.IsNil() {
+				if ctx.booleanError {
+					return booleanError
+				}
+				return &Error{
+					Context:  ctx,
+					Message:  "nil pointer",
+					Got:      rawString("nil " + got.Type().String()),
+					Expected: rawString(expected.Type().String()),
+				}
+			}
+			return deepValueEqualByName(ctx.AddPtr(1), got.Elem(), expected, tag)
+		}
+
+		if got.IsNil() || expected.IsNil() {
+			if got.IsNil() == expected.IsNil() {
+				return nil
+			}
+			if ctx.booleanError {
+				return booleanError
+			}
+			return &Error{
+				Context:  ctx,
+				Message:  "nil pointer",
+				Got:      isNilStr(got.IsNil()),
+				Expected: isNilStr(expected.IsNil()),
+			}
+		}
+		return deepValueEqual(ctx.AddPtr(1), got.Elem(), expected.Elem())
+	}
+
+	gotEntries := removeIgnored(ctx, byNameEntries(got, tag))
+	expectedEntries := removeIgnored(ctx, byNameEntries(expected, tag))
+
+	var missing, extra []reflect.Value
+
+	gotIdx := 0
+	for _, expectedEntry := range expectedEntries {
+		for gotIdx < len(gotEntries) && gotEntries[gotIdx].name < expectedEntry.name {
+			if ctx.booleanError {
+				return booleanError
+			}
+			extra = append(extra, reflect.ValueOf(rawString(gotEntries[gotIdx].name)))
+			gotIdx++
+		}
+
+		if gotIdx == len(gotEntries) || gotEntries[gotIdx].name != expectedEntry.name {
+			if ctx.booleanError {
+				return booleanError
+			}
+			missing = append(missing, reflect.ValueOf(rawString(expectedEntry.name)))
+			continue
+		}
+
+		gotEntry := gotEntries[gotIdx]
+		gotIdx++
+
+		var entryCtx Context
+		if gotEntry.key.IsValid() {
+			entryCtx = ctx.AddMapKey(gotEntry.key)
+		} else {
+			entryCtx = ctx.AddField(gotEntry.name)
+		}
+
+		gotValue, expectedValue := gotEntry.value, expectedEntry.value
+		// A nil map value is compared as a typed nil
+		if !expectedValue.IsValid() && gotValue.IsValid() {
+			if nilVal, ok := nilValue(gotValue.Type()); ok {
+				expectedValue = nilVal
+			}
+		} else if !gotValue.IsValid() && expectedValue.IsValid() &&
+			!expectedValue.Type().Implements(testDeeper) {
+			if nilVal, ok := nilValue(expectedValue.Type()); ok {
+				gotValue = nilVal
+			}
+		}
+
+		if err := deepValueEqual(entryCtx, gotValue, expectedValue); err != nil {
+			return err
+		}
+	}
+
+	for ; gotIdx < len(gotEntries); gotIdx++ {
+		if ctx.booleanError {
+			return booleanError
+		}
+		extra = append(extra, reflect.ValueOf(rawString(gotEntries[gotIdx].name)))
+	}
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	return &Error{
+		Context: ctx,
+		Message: "comparing fields by name",
+		Summary: tdSetResult{
+			Kind:    fieldsSetResult,
+			Missing: missing,
+			Extra:   extra,
+		},
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+type likeAddressDTO struct {
+	City string `json:"city"`
+}
+
+type likePersonDTO struct {
+	Name     string          `json:"name"`
+	Age      int             `json:"age,omitempty"`
+	Address  *likeAddressDTO `json:"address"`
+	Password string          `json:"-"`
+	secret   string
+}
+
+type likeAddress struct {
+	City string
+}
+
+type likePerson struct {
+	Name     string
+	Age      int
+	Address  *likeAddress
+	Password string
+	secret   string
+}
+
+func TestStructLike(t *testing.T) {
+	got := likePersonDTO{
+		Name:     "Bob",
+		Age:      42,
+		Address:  &likeAddressDTO{City: "Paris"},
+		Password: "pass",
+		secret:   "xxx",
+	}
+
+	checkOK(t, got, StructLike(likePerson{
+		Name:     "Bob",
+		Age:      42,
+		Address:  &likeAddress{City: "Paris"},
+		Password: "pass",
+		secret:   "xxx",
+	}))
+
+	checkOK(t, &got, StructLike(&likePerson{
+		Name:     "Bob",
+		Age:      42,
+		Address:  &likeAddress{City: "Paris"},
+		Password: "pass",
+		secret:   "xxx",
+	}))
+
+	checkOK(t, got, StructLike(map[string]interface{}{
+		"Name":     "Bob",
+		"Age":      Between(40, 45),
+		"Address":  StructLike(likeAddress{City: "Paris"}),
+		"Password": "pass",
+		"secret":   "xxx",
+	}))
+
+	checkOK(t, got, StructLike(map[string]interface{}{
+		"name":    Re("^B"),
+		"age":     42,
+		"address": map[string]interface{}{"city": "Paris"},
+		"secret":  "xxx",
+	}, "json"))
+
+	// A map against a struct
+	checkOK(t, map[string]interface{}{"Name": "Bob", "Address": nil},
+		StructLike(struct {
+			Name    string
+			Address *likeAddress
+		}{Name: "Bob"}))
+
+	checkError(t, got,
+		StructLike(likePerson{
+			Name:     "Bob",
+			Age:      42,
+			Address:  &likeAddress{City: "Lyon"},
+			Password: "pass",
+			secret:   "xxx",
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("(*DATA.Address).City"),
+			Got:      mustBe(`(string) (len=5) "Paris"`),
+			Expected: mustBe(`(string) (len=4) "Lyon"`),
+		})
+
+	checkError(t, got,
+		StructLike(map[string]interface{}{
+			"name":     "Bob",
+			"age":      42,
+			"address":  nil,
+			"secret":   "xxx",
+			"password": "pass",
+		}, "json"),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("*DATA.address"),
+			Got:      mustContain("Paris"),
+			Expected: mustBe("nil"),
+		})
+
+	checkError(t, got,
+		StructLike(map[string]interface{}{
+			"name":     "Bob",
+			"age":      42,
+			"address":  Ignore(),
+			"password": "pass",
+		}, "json"),
+		expectedError{
+			Message: mustBe("comparing fields by name"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing fields: (password)\n  Extra fields: (secret)"),
+		})
+
+	checkError(t, map[string]int{"Name": 12}, StructLike(likeAddress{}),
+		expectedError{
+			Message: mustBe("comparing fields by name"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing fields: (City)\n  Extra fields: (Name)"),
+		})
+
+	checkError(t, (*likePersonDTO)(nil), StructLike(likePerson{}),
+		expectedError{
+			Message:  mustBe("nil ptr"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("*testdeep_test.likePersonDTO(nil)"),
+			Expected: mustBe("testdeep_test.likePerson like"),
+		})
+
+	checkError(t, 12, StructLike(likePerson{}),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("struct or map with string keys"),
+		})
+
+	checkError(t, map[int]string{}, StructLike(likePerson{}),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("map[int]string"),
+			Expected: mustBe("struct or map with string keys"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { StructLike(12) }, "usage: StructLike(")
+	checkPanic(t, func() { StructLike(nil) }, "usage: StructLike(")
+	checkPanic(t, func() { StructLike(map[int]bool{}) }, "usage: StructLike(")
+	checkPanic(t, func() { StructLike(likePerson{}, "json", "yaml") },
+		"usage: StructLike(")
+
+	//
+	// String
+	equalStr(t, StructLike(likeAddress{City: "Paris"}).String(),
+		`StructLike(testdeep_test.likeAddress{
+  City: (string) (len=5) "Paris"
+})`)
+	equalStr(t, StructLike(map[string]interface{}{}, "json").String(),
+		`StructLike(map[string]interface {}{}, "json")`)
+
+	//
+	// TypeBehind
+	equalTypes(t, StructLike(likePerson{}), nil)
+}
+
+func TestStructsByName(t *testing.T) {
+	got := likePersonDTO{
+		Name:    "Bob",
+		Address: &likeAddressDTO{City: "Paris"},
+	}
+
+	expected := []interface{}{
+		12,
+		&likePerson{Name: "Bob", Address: &likeAddress{City: "Paris"}},
+	}
+
+	// Disabled by default
+	if EqDeeply([]interface{}{12, &got}, expected) {
+		t.Error("structs of different types should not match by default")
+	}
+
+	tt := NewT(&testing.T{}, ContextConfig{StructsByName: true})
+	if !tt.CmpDeeply([]interface{}{12, &got}, expected) {
+		t.Error("structs of different types should match by name")
+	}
+	if !tt.CmpDeeply(got, Any(12, likePerson{
+		Name:    "Bob",
+		Address: &likeAddress{City: "Paris"},
+	})) {
+		t.Error("structs of different types should match by name in Any")
+	}
+	if tt.CmpDeeply(got, likePerson{Name: "Alice"}) {
+		t.Error("structs with different field values should not match")
+	}
+
+	tt = NewT(&testing.T{}, ContextConfig{
+		StructsByName:    true,
+		StructsByNameTag: "json",
+	})
+	if !tt.CmpDeeply(got, map[string]interface{}{
+		"name":    "Bob",
+		"age":     0,
+		"address": map[string]interface{}{"city": "Paris"},
+		"secret":  "",
+	}) {
+		t.Error("struct should match a map by json tag")
+	}
+	if !tt.CmpDeeply(&got, &struct {
+		Name    string `json:"name"`
+		Age     int    `json:"age"`
+		Address *struct {
+			Town string `json:"city"`
+		} `json:"address"`
+		Secret string `json:"secret"`
+	}{Name: "Bob", Address: &struct {
+		Town string `json:"city"`
+	}{Town: "Paris"}}) {
+		t.Error("structs of different types should match by json tag")
+	}
+}
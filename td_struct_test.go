@@ -232,6 +232,103 @@ func TestStruct(t *testing.T) {
 
 	equalStr(t, Struct(&MyStruct{}, StructFields{}).String(),
 		`Struct(*testdeep_test.MyStruct{})`)
+
+	// Nested operators
+	equalStr(t, Struct(MyStruct{}, StructFields{
+		"ValInt": Bag(1, 2),
+		"MyStructMid": Struct(MyStructMid{}, StructFields{
+			"ValStr": "foobar",
+		}),
+	}).String(),
+		`Struct(testdeep_test.MyStruct{
+  MyStructMid: Struct(testdeep_test.MyStructMid{
+    ValStr: (string) (len=6) "foobar"
+  })
+  ValInt: Bag((int) 1,
+              (int) 2)
+})`)
+
+	// Continuation lines stay aligned in nested blocks
+	equalStr(t, Struct(MyStruct{}, StructFields{
+		"MyStructMid": Struct(MyStructMid{}, StructFields{
+			"ValStr": Any("foo", "bar"),
+		}),
+	}).String(),
+		`Struct(testdeep_test.MyStruct{
+  MyStructMid: Struct(testdeep_test.MyStructMid{
+    ValStr: Any((string) (len=3) "foo",
+                (string) (len=3) "bar")
+  })
+})`)
+}
+
+func TestSStruct(t *testing.T) {
+	var gotStruct = MyStruct{
+		MyStructMid: MyStructMid{
+			ValStr: "foobar",
+		},
+		ValInt: 123,
+	}
+
+	checkOK(t, gotStruct,
+		SStruct(MyStruct{}, StructFields{
+			"ValStr": "foobar",
+			"ValInt": 123,
+		}))
+
+	checkOK(t, &gotStruct,
+		SStruct(&MyStruct{ValInt: 123}, StructFields{
+			"ValStr": Re("^foo"),
+			"Ptr":    nil,
+		}))
+
+	// Zero fields of model are checked
+	gotStruct.ValBool = true
+	checkError(t, gotStruct,
+		SStruct(MyStruct{}, StructFields{
+			"ValStr": "foobar",
+			"ValInt": 123,
+		}),
+		expectedError{
+			Message: mustBe("field ValBool is not zero (absent from StructFields and zero in model)"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("unchecked fields: Ptr, ValBool"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA.ValBool"),
+				Got:      mustBe("(bool) true"),
+				Expected: mustBe("(bool) false"),
+			},
+		})
+
+	// Non-zero fields of model are reported as with Struct
+	checkError(t, gotStruct,
+		SStruct(MyStruct{ValInt: 124}, StructFields{
+			"ValStr":  "foobar",
+			"ValBool": true,
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.ValInt"),
+			Got:      mustBe("(int) 123"),
+			Expected: mustBe("(int) 124"),
+		})
+
+	checkPanic(t, func() { SStruct(12, nil) },
+		"usage: SStruct(STRUCT|&STRUCT, EXPECTED_FIELDS)")
+
+	//
+	// String
+	equalStr(t, SStruct(MyStruct{ValInt: 123}, StructFields{
+		"ValBool": false,
+	}).String(),
+		`SStruct(testdeep_test.MyStruct{
+  ValBool: (bool) false
+  ValInt: (int) 123
+})`)
+
+	equalStr(t, SStruct(&MyStruct{}, nil).String(),
+		`SStruct(*testdeep_test.MyStruct{})`)
 }
 
 func TestStructPrivateFields(t *testing.T) {
@@ -368,6 +465,264 @@ func TestStructPrivateFields(t *testing.T) {
 }
 
 func TestStructTypeBehind(t *testing.T) {
+	equalTypes(t, SStruct(MyStruct{}, nil), MyStruct{})
+	equalTypes(t, SStruct(&MyStruct{}, nil), &MyStruct{})
+
 	equalTypes(t, Struct(MyStruct{}, nil), MyStruct{})
 	equalTypes(t, Struct(&MyStruct{}, nil), &MyStruct{})
 }
+
+func TestStructNestedFields(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  int
+	}
+	type Owner struct {
+		Name    string
+		Address *Address
+	}
+	type Item struct {
+		ID    int
+		Owner Owner
+	}
+
+	got := Item{
+		ID: 12,
+		Owner: Owner{
+			Name:    "Bob",
+			Address: &Address{City: "Paris", Zip: 75001},
+		},
+	}
+
+	checkOK(t, got,
+		Struct(Item{}, StructFields{
+			"Owner.Address.City": "Paris",
+			"Owner.Name":         Re("^B"),
+		}))
+
+	checkError(t, got,
+		Struct(Item{}, StructFields{
+			"Owner.Address.City": "Lyon",
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Owner.Address.City"),
+			Got:      mustBe(`(string) (len=5) "Paris"`),
+			Expected: mustBe(`(string) (len=4) "Lyon"`),
+		})
+
+	got.Owner.Address = nil
+	checkError(t, got,
+		Struct(Item{}, StructFields{
+			"Owner.Address.City": "Paris",
+		}),
+		expectedError{
+			Message:  mustBe("nil pointer, cannot reach field Owner.Address.City"),
+			Path:     mustBe("DATA.Owner.Address"),
+			Got:      mustBe("nil"),
+			Expected: mustBe("non-nil *testdeep_test.Address"),
+		})
+
+	// Nested fields do not make their parent unchecked in SStruct
+	checkOK(t, got,
+		SStruct(Item{}, StructFields{
+			"ID":         12,
+			"Owner.Name": "Bob",
+		}))
+
+	// but the other fields of their parent are still checked
+	got.Owner.Address = &Address{City: "Paris"}
+	checkError(t, got,
+		SStruct(Item{}, StructFields{
+			"ID":         12,
+			"Owner.Name": "Bob",
+		}),
+		expectedError{
+			Message: mustBe("field Owner.Address is not zero (absent from StructFields and zero in model)"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("unchecked fields: Owner.Address"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("*DATA.Owner.Address"),
+				Expected: mustBe("nil"),
+			},
+		})
+	got.Owner.Address.Zip = 75001
+	checkError(t, got,
+		SStruct(Item{}, StructFields{
+			"ID":                 12,
+			"Owner.Name":         "Bob",
+			"Owner.Address.City": "Paris",
+		}),
+		expectedError{
+			Message: mustBe("field Owner.Address.Zip is not zero (absent from StructFields and zero in model)"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("unchecked fields: Owner.Address.Zip"),
+			Origin: &expectedError{
+				Message:  mustBe("values differ"),
+				Path:     mustBe("DATA.Owner.Address.Zip"),
+				Got:      mustBe("(int) 75001"),
+				Expected: mustBe("(int) 0"),
+			},
+		})
+	got.Owner.Address.Zip = 0
+	checkOK(t, got,
+		SStruct(Item{}, StructFields{
+			"ID":                 12,
+			"Owner.Name":         "Bob",
+			"Owner.Address.City": "Paris",
+		}))
+
+	// Non-zero fields of model are still checked, except those
+	// overridden by a nested key
+	got.Owner.Address.Zip = 75001
+	checkOK(t, got,
+		Struct(Item{Owner: Owner{Name: "Bob", Address: &Address{Zip: 75001}}},
+			StructFields{"Owner.Address.City": "Paris"}))
+	checkError(t, got,
+		Struct(Item{Owner: Owner{Name: "Alice"}},
+			StructFields{"Owner.Address.City": "Paris"}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.Owner.Name"),
+			Got:      mustBe(`(string) (len=3) "Bob"`),
+			Expected: mustBe(`(string) (len=5) "Alice"`),
+		})
+	got.Owner.Address = nil
+
+	//
+	// Bad usage
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"Owner.Address.Town": "Paris"}) },
+		"struct testdeep_test.Address has no field `Town' (in `Owner.Address.Town')")
+
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"ID.Value": 12}) },
+		"field ID of struct testdeep_test.Item is neither a struct nor a pointer on a struct, so cannot reach `ID.Value'")
+
+	checkPanic(t,
+		func() {
+			Struct(Item{Owner: Owner{Name: "Bob"}},
+				StructFields{"Owner": Owner{}})
+		},
+		"non zero field Owner in model already exists in expectedFields")
+
+	checkPanic(t,
+		func() {
+			Struct(Item{Owner: Owner{Name: "Bob"}},
+				StructFields{"Owner.Name": Re("^B")})
+		},
+		"non zero field Owner.Name in model already exists in expectedFields")
+
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"Owner.Address.Zip": "75001"}) },
+		"type string of field expected value Owner.Address.Zip differs from struct one (int)")
+
+	// Closest field name suggestion
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"Ownr": nil}) },
+		"struct testdeep_test.Item has no field `Ownr', did you mean `Owner'?")
+
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"id": 12}) },
+		"struct testdeep_test.Item has no field `id', did you mean `ID'?")
+
+	checkPanic(t,
+		func() { Struct(Item{}, StructFields{"Foobar": 12}) },
+		"struct testdeep_test.Item has no field `Foobar'")
+
+	//
+	// String
+	equalStr(t, Struct(Item{}, StructFields{
+		"Owner.Address.City": "Paris",
+	}).String(),
+		`Struct(testdeep_test.Item{
+  Owner.Address.City: (string) (len=5) "Paris"
+})`)
+}
+
+func TestStructPatternFields(t *testing.T) {
+	type Resource struct {
+		ID         int
+		CreatedAt  time.Time
+		CreatedBy  string
+		MetaSource string
+		MetaTag    string
+	}
+
+	now := time.Now()
+	got := Resource{
+		ID:         42,
+		CreatedAt:  now,
+		CreatedBy:  "admin",
+		MetaSource: "api",
+		MetaTag:    "api",
+	}
+
+	checkOK(t, got,
+		Struct(Resource{ID: 42}, StructFields{
+			"=~^Created": Not(Zero()),
+			"Meta*":      "api",
+		}))
+
+	// Explicit fields take precedence over patterns
+	checkOK(t, got,
+		Struct(Resource{}, StructFields{
+			"MetaSource": Len(3),
+			"Meta*":      Re("^ap"),
+		}))
+
+	checkError(t, got,
+		Struct(Resource{}, StructFields{
+			"Meta[ST]*": "api",
+			"MetaTag":   "web",
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.MetaTag"),
+			Got:      mustBe(`(string) (len=3) "api"`),
+			Expected: mustBe(`(string) (len=3) "web"`),
+		})
+
+	checkError(t, got,
+		Struct(Resource{}, StructFields{
+			"=~By$": "root",
+		}),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA.CreatedBy"),
+			Got:      mustBe(`(string) (len=5) "admin"`),
+			Expected: mustBe(`(string) (len=4) "root"`),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t,
+		func() { Struct(Resource{}, StructFields{"=~(": 12}) },
+		"bad StructFields regexp `=~(': ")
+
+	checkPanic(t,
+		func() { Struct(Resource{}, StructFields{"Meta[": 12}) },
+		"bad StructFields pattern `Meta[': ")
+
+	checkPanic(t,
+		func() { Struct(Resource{}, StructFields{"Foo*": 12}) },
+		"StructFields pattern `Foo*' matches no field of struct testdeep_test.Resource")
+
+	checkPanic(t,
+		func() {
+			Struct(Resource{}, StructFields{
+				"=~Tag$": "api",
+				"Meta*":  "api",
+			})
+		},
+		"field MetaTag is matched by several StructFields patterns: `=~Tag$' & `Meta*'")
+
+	checkPanic(t,
+		func() { Struct(Resource{}, StructFields{"Created*": 12}) },
+		"type int of field expected value CreatedAt differs from struct one (time.Time)")
+
+	checkPanic(t,
+		func() { Struct(Resource{ID: 42}, StructFields{"I?": 42}) },
+		"non zero field ID in model already exists in expectedFields")
+}
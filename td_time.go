@@ -0,0 +1,206 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"time"
+)
+
+type timeOp uint8
+
+const (
+	timeRecent timeOp = iota
+	timeWithin
+	timeBefore
+	timeAfter
+)
+
+type tdTime struct {
+	Base
+	op           timeOp
+	expectedType reflect.Type // nil for Recent
+	expectedTime time.Time
+	delta        time.Duration
+}
+
+var _ TestDeep = &tdTime{}
+
+// Recent operator checks that data is a time.Time (or convertible)
+// value within "d" of the time of the comparison:
+//   time.Now() - d ≤ got ≤ time.Now() + d
+// So there is no need to capture timestamps around the tested code.
+// The time of the comparison is read once per CmpDeeply call, so the
+// failure report describes the "now" the decision was based on.
+//
+//   CmpDeeply(t, record.CreatedAt, Recent(time.Second))
+//
+// TypeBehind method returns the reflect.Type of time.Time.
+func Recent(d time.Duration) TestDeep {
+	if d < 0 {
+		panic("usage: Recent(time.Duration), duration must be >= 0")
+	}
+	return &tdTime{
+		Base:  NewBase(3),
+		op:    timeRecent,
+		delta: d,
+	}
+}
+
+// Within operator checks that data is a time.Time (or assignable)
+// value within "d" of "expectedTime":
+//   expectedTime - d ≤ got ≤ expectedTime + d
+// Locations and monotonic clock readings are ignored.
+//
+// TypeBehind method returns the reflect.Type of "expectedTime".
+func Within(expectedTime interface{}, d time.Duration) TestDeep {
+	const usage = "usage: Within(time.Time, time.Duration)"
+	if d < 0 {
+		panic(usage + ", duration must be >= 0")
+	}
+	t := newTime(timeWithin, expectedTime, usage)
+	t.delta = d
+	return t
+}
+
+// Before operator checks that data is a time.Time (or assignable)
+// value strictly before "expectedTime". On failure, the error
+// reports how far after "expectedTime" data is. Locations and
+// monotonic clock readings are ignored.
+//
+// TypeBehind method returns the reflect.Type of "expectedTime".
+func Before(expectedTime interface{}) TestDeep {
+	return newTime(timeBefore, expectedTime, "usage: Before(time.Time)")
+}
+
+// After operator checks that data is a time.Time (or assignable)
+// value strictly after "expectedTime". On failure, the error reports
+// how far before "expectedTime" data is. Locations and monotonic
+// clock readings are ignored.
+//
+// TypeBehind method returns the reflect.Type of "expectedTime".
+func After(expectedTime interface{}) TestDeep {
+	return newTime(timeAfter, expectedTime, "usage: After(time.Time)")
+}
+
+func newTime(op timeOp, expectedTime interface{}, usage string) *tdTime {
+	t := tdTime{
+		Base: NewBase(4),
+		op:   op,
+	}
+
+	vval := reflect.ValueOf(expectedTime)
+	if !vval.IsValid() {
+		panic(usage)
+	}
+
+	t.expectedType = vval.Type()
+	if t.expectedType == timeType {
+		t.expectedTime = expectedTime.(time.Time)
+		return &t
+	}
+	if t.expectedType.Kind() == reflect.Struct &&
+		t.expectedType.ConvertibleTo(timeType) {
+		t.expectedTime = vval.Convert(timeType).Interface().(time.Time)
+		return &t
+	}
+	panic(usage)
+}
+
+func (t *tdTime) Match(ctx Context, got reflect.Value) *Error {
+	var ok bool
+	if t.op == timeRecent {
+		ok = got.Kind() == reflect.Struct && got.Type().ConvertibleTo(timeType)
+	} else {
+		ok = got.Type() == t.expectedType
+	}
+	if !ok {
+		if ctx.booleanError {
+			return booleanError
+		}
+		return &Error{
+			Context:  ctx,
+			Message:  "type mismatch",
+			Got:      rawString(got.Type().String()),
+			Expected: rawString(t.TypeBehind().String()),
+			Location: t.GetLocation(),
+		}
+	}
+
+	gotTime, err := getTime(ctx, got, got.Type() != timeType)
+	if err != nil {
+		return err
+	}
+
+	ref := t.expectedTime
+	if t.op == timeRecent {
+		ref = ctx.now()
+	}
+
+	switch t.op {
+	case timeRecent, timeWithin:
+		diff := gotTime.Sub(ref)
+		ok = diff >= -t.delta && diff <= t.delta
+	case timeBefore:
+		ok = gotTime.Before(ref)
+	default: // timeAfter
+		ok = gotTime.After(ref)
+	}
+
+	if ok {
+		return nil
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+
+	offset := timeOffset(gotTime, ref)
+	if t.op == timeRecent {
+		offset += " now"
+	}
+	return &Error{
+		Context: ctx,
+		Message: "values differ",
+		Got: rawString(ctx.timeString(gotTime, ref.Location()) +
+			"\n(" + offset + ")"),
+		Expected: rawString(t.describe(ctx.timeString(ref, nil))),
+		Location: t.GetLocation(),
+	}
+}
+
+// describe returns the expected value as displayed in errors and
+// by String method, "ref" being the reference time.
+func (t *tdTime) describe(ref string) string {
+	switch t.op {
+	case timeRecent:
+		if ref == "" {
+			return "within " + t.delta.String() + " of now"
+		}
+		return "within " + t.delta.String() + " of now (" + ref + ")"
+	case timeWithin:
+		return ref + " ± " + t.delta.String()
+	case timeBefore:
+		return "before " + ref
+	default: // timeAfter
+		return "after " + ref
+	}
+}
+
+func (t *tdTime) String() string {
+	if t.op == timeRecent {
+		return t.describe("")
+	}
+	return t.describe(t.expectedTime.String())
+}
+
+func (t *tdTime) TypeBehind() reflect.Type {
+	if t.expectedType == nil {
+		return timeType
+	}
+	return t.expectedType
+}
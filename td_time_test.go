@@ -0,0 +1,164 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestRecent(t *testing.T) {
+	now := time.Now()
+
+	checkOK(t, now, Recent(time.Second))
+	checkOK(t, now.Add(-time.Minute), Recent(2*time.Minute))
+	checkOK(t, now.Add(time.Minute), Recent(2*time.Minute))
+	checkOK(t, now.UTC(), Recent(time.Second))
+	checkOK(t, MyTime(now), Recent(time.Second))
+	checkOK(t, MyTimeStr(now), Recent(time.Second))
+
+	checkError(t, now.Add(-time.Hour), Recent(time.Minute),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustMatch(`\n\(1h0m0(\.\d+)?s before now\)\z`),
+			Expected: mustMatch(`^within 1m0s of now \(.+\)\z`),
+		})
+	checkError(t, now.Add(time.Hour), Recent(time.Minute),
+		expectedError{
+			Message:  mustBe("values differ"),
+			Path:     mustBe("DATA"),
+			Got:      mustMatch(`\n\((59m59\.\d+s|1h0m0s) after now\)\z`),
+			Expected: mustMatch(`^within 1m0s of now \(.+\)\z`),
+		})
+
+	checkError(t, 12, Recent(time.Minute),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("time.Time"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Recent(-time.Second) }, "usage: Recent(")
+
+	//
+	// String
+	equalStr(t, Recent(time.Minute).String(), "within 1m0s of now")
+}
+
+func TestWithin(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+	expDate := time.Date(2018, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	checkOK(t, expDate, Within(expDate, 0))
+	checkOK(t, expDate.Add(time.Second), Within(expDate, time.Second))
+	checkOK(t, expDate.Add(-time.Second), Within(expDate, time.Second))
+	checkOK(t, expDate.In(paris), Within(expDate, 0))
+	checkOK(t, MyTime(expDate), Within(MyTime(expDate.Add(time.Second)), time.Second))
+
+	checkError(t, expDate.Add(90*time.Second), Within(expDate, time.Minute),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 01:03:33 +0000 UTC\n" +
+				"(1m30s after)"),
+			Expected: mustBe("2018-03-09 01:02:03 +0000 UTC ± 1m0s"),
+		})
+
+	// got is displayed in the expected location
+	checkError(t, expDate.Add(-2*time.Hour), Within(expDate.In(paris), time.Hour),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 00:02:03 +0100 CET\n" +
+				"(2h0m0s before)"),
+			Expected: mustBe("2018-03-09 02:02:03 +0100 CET ± 1h0m0s"),
+		})
+
+	checkError(t, expDate, Within(MyTime(expDate), time.Second),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("time.Time"),
+			Expected: mustBe("testdeep_test.MyTime"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Within(expDate, -time.Second) }, "usage: Within(")
+	checkPanic(t, func() { Within("test", time.Second) }, "usage: Within(")
+	checkPanic(t, func() { Within(nil, time.Second) }, "usage: Within(")
+
+	//
+	// String
+	equalStr(t, Within(expDate, time.Minute).String(),
+		"2018-03-09 01:02:03 +0000 UTC ± 1m0s")
+}
+
+func TestBeforeAfter(t *testing.T) {
+	expDate := time.Date(2018, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	checkOK(t, expDate.Add(-time.Nanosecond), Before(expDate))
+	checkOK(t, expDate.Add(time.Nanosecond), After(expDate))
+	checkOK(t, MyTime(expDate.Add(time.Hour)), After(MyTime(expDate)))
+
+	checkError(t, expDate.Add(90*time.Minute), Before(expDate),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 02:32:03 +0000 UTC\n" +
+				"(1h30m0s after)"),
+			Expected: mustBe("before 2018-03-09 01:02:03 +0000 UTC"),
+		})
+	checkError(t, expDate, Before(expDate),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 01:02:03 +0000 UTC\n" +
+				"(same instant)"),
+			Expected: mustBe("before 2018-03-09 01:02:03 +0000 UTC"),
+		})
+	checkError(t, expDate.Add(-2*time.Second), After(expDate),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 01:02:01 +0000 UTC\n" +
+				"(2s before)"),
+			Expected: mustBe("after 2018-03-09 01:02:03 +0000 UTC"),
+		})
+
+	checkError(t, MyTime(expDate), Before(expDate),
+		expectedError{
+			Message:  mustBe("type mismatch"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("testdeep_test.MyTime"),
+			Expected: mustBe("time.Time"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Before(12) }, "usage: Before(")
+	checkPanic(t, func() { After("test") }, "usage: After(")
+
+	//
+	// String
+	equalStr(t, Before(expDate).String(), "before 2018-03-09 01:02:03 +0000 UTC")
+	equalStr(t, After(expDate).String(), "after 2018-03-09 01:02:03 +0000 UTC")
+}
+
+func TestTimeTypeBehind(t *testing.T) {
+	equalTypes(t, Recent(time.Second), time.Time{})
+	equalTypes(t, Within(time.Time{}, time.Second), time.Time{})
+	equalTypes(t, Within(MyTime{}, time.Second), MyTime{})
+	equalTypes(t, Before(MyTime{}), MyTime{})
+	equalTypes(t, After(time.Time{}), time.Time{})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type tdTimes struct {
+	Base
+	item  reflect.Value
+	count reflect.Value // int or TestDeep operator
+}
+
+var _ TestDeep = &tdTimes{}
+
+var timesType = reflect.TypeOf(&tdTimes{})
+
+// Times operator can only be used as an item of Bag, SubBagOf or
+// SuperBagOf operators. It stands for "count" items matching
+// "expectedValue", so there is no need to repeat it.
+//
+// "count" can be an int value:
+//   Bag(Times(1, 50), 2) // fifty 1 and one 2
+// as well as an other operator:
+//   Bag(Times(1, Between(1, 3)), 2) // one to three 1 and one 2
+//
+// When "count" is an int, Times consumes at most "count" matching
+// items. When "count" is an operator, Times is handled after all
+// other expected items, then consumes the greatest number of
+// remaining matching items accepted by "count".
+func Times(expectedValue interface{}, count interface{}) TestDeep {
+	t := tdTimes{
+		Base:  NewBase(3),
+		item:  reflect.ValueOf(expectedValue),
+		count: reflect.ValueOf(count),
+	}
+
+	switch count.(type) {
+	case int:
+		if t.count.Int() >= 0 {
+			return &t
+		}
+	case TestDeep:
+		return &t
+	}
+	panic("usage: Times(EXPECTED_VALUE, INT|TESTDEEP_OPERATOR)")
+}
+
+// fixedCount returns the count of t and true if it is an int.
+func (t *tdTimes) fixedCount() (int, bool) {
+	if t.count.Kind() == reflect.Int {
+		return int(t.count.Int()), true
+	}
+	return 0, false
+}
+
+// bestCount returns the greatest count between 0 and "max" accepted
+// by t count operator, or -1 if none is accepted.
+func (t *tdTimes) bestCount(ctx Context, max int) int {
+	vcount := reflect.New(intType).Elem()
+	for count := max; count >= 0; count-- {
+		vcount.SetInt(int64(count))
+		if deepValueEqual(ctx.boolean(), vcount, t.count) == nil {
+			return count
+		}
+	}
+	return -1
+}
+
+func (t *tdTimes) Match(ctx Context, got reflect.Value) *Error {
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context:  ctx,
+		Message:  "misplaced Times operator",
+		Summary:  rawString("Times can only be used as an item of Bag, SubBagOf or SuperBagOf"),
+		Location: t.GetLocation(),
+	}
+}
+
+func (t *tdTimes) String() string {
+	const prefix = "Times("
+
+	content := toString(t.item)
+	if strings.Contains(content, "\n") {
+		content = indentString(content, strings.Repeat(" ", len(prefix)))
+	}
+
+	var count string
+	if c, ok := t.fixedCount(); ok {
+		count = fmt.Sprint(c)
+	} else {
+		count = toString(t.count)
+	}
+	return prefix + content + ", " + count + ")"
+}
+
+func (t *tdTimes) treeChildren() []treeChild {
+	return []treeChild{{value: t.item}}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestTimes(t *testing.T) {
+	got := []int{1, 2, 1, 1, 3, 1}
+
+	checkOK(t, got, Bag(Times(1, 4), 2, 3))
+	checkOK(t, got, Bag(3, Times(1, 4), Times(2, 1), Times(4, 0)))
+	checkOK(t, got, Bag(Times(Lt(3), 5), 3))
+	checkOK(t, got, Bag(Times(1, Gte(1)), 2, 3))
+	checkOK(t, got, Bag(Times(Gt(0), Gte(1)), 2, 3)) // handled last
+	checkOK(t, got, Bag(Times(1, Between(2, 4)), 2, 3))
+	checkOK(t, got, SubBagOf(Times(1, 10), 2, 3, 4))
+	checkOK(t, got, SubBagOf(Times(1, Lte(5)), 2, 3))
+	checkOK(t, got, SuperBagOf(Times(1, 2), 3))
+	checkOK(t, got, SuperBagOf(Times(1, Gte(2)), 3))
+	checkOK(t, got, SuperBagOf(Times(1, Lte(2)), 3))
+
+	checkError(t, got, Bag(Times(1, 6), 2, 3),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing items: ((int) 1 ×2)"),
+		})
+
+	checkError(t, got, Bag(Times(1, 3), 2, 3),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Extra items: ((int) 1)"),
+		})
+
+	checkError(t, got, Bag(Times(1, 2), 2),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Extra items: ((int) 1 ×2,\n              (int) 3)"),
+		})
+
+	checkError(t, got, Bag(Times(1, Lte(3)), 2, 3),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Extra items: ((int) 1)"),
+		})
+
+	checkError(t, got, Bag(Times(1, Gte(5)), 2, 3),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing items: (Times((int) 1, ≥ 5))\n  Extra items: ((int) 1 ×4)"),
+		})
+
+	many := make([]string, 10000)
+	for i := range many {
+		many[i] = []string{"a", "b"}[i%2]
+	}
+	checkError(t, many, Bag(Times("a", 5000)),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe(`Extra items: ((string) (len=1) "b" ×5000)`),
+		})
+
+	// Only scalar items are grouped
+	checkError(t, []interface{}{[]int{1}, []int{1}}, Bag(),
+		expectedError{
+			Message: mustBe("comparing %% as a Bag"),
+			Path:    mustBe("DATA"),
+			Summary: mustMatch(`^Extra items: \(\(\[\]int\)[^×]+\)\z`),
+		})
+
+	checkError(t, got, SubBagOf(Times(1, 3), 2, 3),
+		expectedError{
+			Message: mustBe("comparing %% as a SubBagOf"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Extra items: ((int) 1)"),
+		})
+
+	checkError(t, got, SuperBagOf(Times(1, 5)),
+		expectedError{
+			Message: mustBe("comparing %% as a SuperBagOf"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Missing items: ((int) 1)"),
+		})
+
+	// Misplaced Times
+	checkError(t, 1, Times(1, 1),
+		expectedError{
+			Message: mustBe("misplaced Times operator"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Times can only be used as an item of Bag, SubBagOf or SuperBagOf"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Times(1, -1) }, "usage: Times(")
+	checkPanic(t, func() { Times(1, "x") }, "usage: Times(")
+	checkPanic(t, func() { Set(Times(1, 2)) },
+		"Set: Times can only be used in Bag, SubBagOf or SuperBagOf")
+	checkPanic(t, func() { NoneOf(Times(1, 2)) },
+		"NoneOf: Times can only be used in Bag, SubBagOf or SuperBagOf")
+
+	//
+	// String
+	equalStr(t, Times(1, 2).String(), "Times((int) 1, 2)")
+	equalStr(t, Times(1, Gte(2)).String(), "Times((int) 1, ≥ 2)")
+	equalStr(t, Bag(Times(1, 2), 3).String(),
+		"Bag(Times((int) 1, 2),\n    (int) 3)")
+}
+
+func TestTimesTypeBehind(t *testing.T) {
+	equalTypes(t, Times(1, 2), nil)
+}
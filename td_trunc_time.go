@@ -17,6 +17,7 @@ type tdTruncTime struct {
 	expectedType reflect.Type
 	expectedTime time.Time
 	trunc        time.Duration
+	round        bool // RoundTime instead of TruncTime
 }
 
 var _ TestDeep = &tdTruncTime{}
@@ -30,11 +31,42 @@ var _ TestDeep = &tdTruncTime{}
 // Whatever the "trunc" value is, the monotonic clock is stripped
 // before the comparison against "expectedTime".
 //
+// Times are compared as instants, as time.Time.Equal does: their
+// locations are never compared, whatever ContextConfig.TimeLocation
+// and ContextConfig.IgnoreTimeLocation are. Note that, like
+// time.Truncate, truncation is done on the absolute time, so
+// truncating to 24h truncates to midnight UTC, not to midnight in the
+// location of the times.
+//
+// In failure reports, the got time is displayed in the location of
+// "expectedTime" (or in ContextConfig.TimeLocation if set), so both
+// can easily be compared. This only affects how times are displayed.
+//
 // TypeBehind method returns the reflect.Type of "expectedTime".
 func TruncTime(expectedTime interface{}, trunc ...time.Duration) TestDeep {
+	return newTruncTime(expectedTime, trunc, false,
+		"usage: TruncTime(time.Time[, time.Duration])")
+}
+
+// RoundTime operator is the same as TruncTime operator except that
+// values are rounded to the optional "round" duration instead of
+// being truncated. See time.Round for details about the rounding.
+//
+// If "round" is missing, it defaults to 0, so only the monotonic
+// clock is stripped before the comparison against "expectedTime".
+//
+// TypeBehind method returns the reflect.Type of "expectedTime".
+func RoundTime(expectedTime interface{}, round ...time.Duration) TestDeep {
+	return newTruncTime(expectedTime, round, true,
+		"usage: RoundTime(time.Time[, time.Duration])")
+}
+
+func newTruncTime(expectedTime interface{}, trunc []time.Duration,
+	round bool, usage string) TestDeep {
 	if len(trunc) <= 1 {
 		t := tdTruncTime{
-			Base: NewBase(3),
+			Base:  NewBase(4),
+			round: round,
 		}
 
 		if len(trunc) == 1 {
@@ -45,16 +77,24 @@ func TruncTime(expectedTime interface{}, trunc ...time.Duration) TestDeep {
 
 		t.expectedType = vval.Type()
 		if t.expectedType == timeType {
-			t.expectedTime = expectedTime.(time.Time).Truncate(t.trunc)
+			t.expectedTime = t.apply(expectedTime.(time.Time))
 			return &t
 		}
 		if t.expectedType.ConvertibleTo(timeType) {
-			t.expectedTime = vval.Convert(timeType).
-				Interface().(time.Time).Truncate(t.trunc)
+			t.expectedTime = t.apply(vval.Convert(timeType).
+				Interface().(time.Time))
 			return &t
 		}
 	}
-	panic("usage: TruncTime(time.Time[, time.Duration])")
+	panic(usage)
+}
+
+// apply truncates or rounds "tm" depending on t kind.
+func (t *tdTruncTime) apply(tm time.Time) time.Time {
+	if t.round {
+		return tm.Round(t.trunc)
+	}
+	return tm.Truncate(t.trunc)
 }
 
 func (t *tdTruncTime) Match(ctx Context, got reflect.Value) *Error {
@@ -75,7 +115,7 @@ func (t *tdTruncTime) Match(ctx Context, got reflect.Value) *Error {
 	if err != nil {
 		return err
 	}
-	gotTimeTrunc := gotTime.Truncate(t.trunc)
+	gotTimeTrunc := t.apply(gotTime)
 
 	if gotTimeTrunc.Equal(t.expectedTime) {
 		return nil
@@ -93,14 +133,20 @@ func (t *tdTruncTime) Match(ctx Context, got reflect.Value) *Error {
 		gotTruncStr = reflect.ValueOf(gotTimeTrunc).Convert(t.expectedType).
 			Interface().(fmt.Stringer).String()
 	} else {
-		gotRawStr = gotTime.String()
-		gotTruncStr = gotTimeTrunc.String()
+		loc := t.expectedTime.Location()
+		gotRawStr = ctx.timeString(gotTime, loc)
+		gotTruncStr = ctx.timeString(gotTimeTrunc, loc)
+	}
+
+	action := "truncated"
+	if t.round {
+		action = "rounded"
 	}
 
 	return &Error{
 		Context:  ctx,
 		Message:  "values differ",
-		Got:      rawString(gotRawStr + "\ntruncated to:\n" + gotTruncStr),
+		Got:      rawString(gotRawStr + "\n" + action + " to:\n" + gotTruncStr),
 		Expected: t,
 		Location: t.GetLocation(),
 	}
@@ -122,9 +122,80 @@ func TestTruncTime(t *testing.T) {
 	checkPanic(t, func() { TruncTime("test") }, "usage: TruncTime(")
 }
 
+func TestTruncTimeLocation(t *testing.T) {
+	paris := time.FixedZone("CET", 3600)
+
+	gotDate := time.Date(2018, time.March, 9, 1, 2, 3, 4, time.UTC)
+	expDate := time.Date(2018, time.March, 9, 2, 2, 4, 0, paris)
+
+	checkOK(t, gotDate, TruncTime(expDate.Add(-time.Second), time.Second))
+
+	// got is displayed in the expected location
+	checkError(t, gotDate, TruncTime(expDate, time.Second), expectedError{
+		Message: mustBe("values differ"),
+		Path:    mustBe("DATA"),
+		Got: mustBe("2018-03-09 02:02:03.000000004 +0100 CET\n" +
+			"truncated to:\n" +
+			"2018-03-09 02:02:03 +0100 CET"),
+		Expected: mustBe("2018-03-09 02:02:04 +0100 CET"),
+	})
+
+	// Truncation is done on the absolute time, not in the location
+	checkOK(t, time.Date(2018, time.March, 9, 0, 30, 0, 0, paris),
+		TruncTime(time.Date(2018, time.March, 8, 1, 0, 0, 0, paris), 24*time.Hour))
+	checkError(t, time.Date(2018, time.March, 9, 0, 30, 0, 0, paris),
+		TruncTime(time.Date(2018, time.March, 9, 1, 0, 0, 0, paris), 24*time.Hour),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 00:30:00 +0100 CET\n" +
+				"truncated to:\n" +
+				"2018-03-08 01:00:00 +0100 CET"),
+			Expected: mustBe("2018-03-09 01:00:00 +0100 CET"),
+		})
+}
+
+func TestRoundTime(t *testing.T) {
+	gotDate := time.Date(2018, time.March, 9, 1, 2, 3, 600000000, time.UTC)
+
+	checkOK(t, gotDate, RoundTime(gotDate))
+	checkOK(t, gotDate, RoundTime(gotDate.Add(400*time.Millisecond), time.Second))
+	checkOK(t, gotDate, RoundTime(gotDate.Add(-3*time.Second), time.Minute))
+	checkOK(t, MyTime(gotDate), RoundTime(MyTime(gotDate), time.Second))
+
+	// Monotonic
+	now := time.Now()
+	checkOK(t, now, RoundTime(now.Round(0)))
+
+	checkError(t, gotDate, RoundTime(gotDate.Add(-600*time.Millisecond), time.Second),
+		expectedError{
+			Message: mustBe("values differ"),
+			Path:    mustBe("DATA"),
+			Got: mustBe("2018-03-09 01:02:03.6 +0000 UTC\n" +
+				"rounded to:\n" +
+				"2018-03-09 01:02:04 +0000 UTC"),
+			Expected: mustBe("2018-03-09 01:02:03 +0000 UTC"),
+		})
+
+	checkError(t, MyTimeStr(gotDate), RoundTime(gotDate), expectedError{
+		Message:  mustBe("type mismatch"),
+		Path:     mustBe("DATA"),
+		Got:      mustBe("testdeep_test.MyTimeStr"),
+		Expected: mustBe("time.Time"),
+	})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { RoundTime("test") }, "usage: RoundTime(")
+	checkPanic(t, func() { RoundTime(gotDate, time.Second, time.Second) },
+		"usage: RoundTime(")
+}
+
 func TestTruncTimeTypeBehind(t *testing.T) {
 	type MyTime time.Time
 
 	equalTypes(t, TruncTime(time.Time{}), time.Time{})
 	equalTypes(t, TruncTime(MyTime{}), MyTime{})
+	equalTypes(t, RoundTime(time.Time{}), time.Time{})
+	equalTypes(t, RoundTime(MyTime{}), MyTime{})
 }
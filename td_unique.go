@@ -0,0 +1,235 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+type tdUnique struct {
+	BaseOKNil
+	key itemKey
+}
+
+var _ TestDeep = &tdUnique{}
+
+// Unique operator checks that an array or a slice (or a pointer on
+// array/slice) does not contain duplicate items. Items are compared
+// as CmpDeeply does.
+//
+// If "by" is a string, it is the name of the struct field (of items
+// or of the structs they point to) that has to be unique instead of
+// the whole item. If "by" is a func(T) K function, it returns the key
+// of each item that has to be unique.
+//
+//   CmpDeeply(t, []int{1, 2, 3}, Unique())                   // succeeds
+//   CmpDeeply(t, []int{1, 2, 1}, Unique())                   // fails
+//   CmpDeeply(t, users, Unique("ID"))                        // succeeds if IDs differ
+//   CmpDeeply(t, []string{"a", "B", "b"}, Unique(strings.ToLower)) // fails
+//
+// In case of failure, the indexes of the first duplicate items are
+// reported.
+func Unique(by ...interface{}) TestDeep {
+	u := tdUnique{
+		BaseOKNil: NewBaseOKNil(3),
+	}
+
+	const usage = "usage: Unique([FIELD|KEY_FUNC])"
+
+	if len(by) > 1 {
+		panic(usage)
+	}
+
+	if len(by) == 1 && by[0] != nil {
+		u.key = newItemKey(by[0], "Unique", usage)
+	}
+
+	return &u
+}
+
+func (u *tdUnique) Match(ctx Context, got reflect.Value) (err *Error) {
+	got, err = getArrayOrSlice(ctx, got, u)
+	if err != nil {
+		return err
+	}
+
+	gotLen := got.Len()
+	if gotLen < 2 {
+		return nil
+	}
+
+	keys := make([]reflect.Value, gotLen)
+	for idx := range keys {
+		keys[idx], err = u.key.get(ctx.AddArrayIndex(idx), got.Index(idx), u)
+		if err != nil {
+			return err
+		}
+	}
+
+	idx := firstDuplicate(ctx, keys)
+	if idx < 0 {
+		return nil
+	}
+	if ctx.booleanError {
+		return booleanError
+	}
+
+	buf := bytes.NewBufferString("Duplicate indexes: ")
+	buf.WriteString(strconv.Itoa(idx))
+	for other := idx + 1; other < gotLen; other++ {
+		if deepValueEqual(ctx.boolean(), keys[other], keys[idx]) == nil {
+			fmt.Fprintf(buf, ", %d", other) // nolint: errcheck
+		}
+	}
+	buf.WriteString("\n  Duplicate value: ")
+	buf.WriteString(indentString(toString(keys[idx]), "                   "))
+
+	return &Error{
+		Context:  ctx,
+		Message:  "duplicate items",
+		Summary:  rawString(buf.String()),
+		Location: u.GetLocation(),
+	}
+}
+
+// firstDuplicate returns the lowest index of "keys" whose key is
+// repeated later, or -1 if all keys are unique. Scalar keys are
+// grouped through a map, the others are compared one to each other.
+func firstDuplicate(ctx Context, keys []reflect.Value) int {
+	first := -1
+
+	var (
+		seen   map[scalarKey]int
+		others []int
+	)
+	for idx, key := range keys {
+		sk, ok := newScalarKey(key)
+		if !ok {
+			others = append(others, idx)
+			continue
+		}
+		if seen == nil {
+			seen = make(map[scalarKey]int, len(keys))
+		}
+		if prev, dup := seen[sk]; dup {
+			if first < 0 || prev < first {
+				first = prev
+			}
+		} else {
+			seen[sk] = idx
+		}
+	}
+
+	for i, idx := range others {
+		if first >= 0 && idx > first {
+			break
+		}
+		for _, other := range others[i+1:] {
+			if deepValueEqual(ctx.boolean(), keys[other], keys[idx]) == nil {
+				return idx
+			}
+		}
+	}
+	return first
+}
+
+func (u *tdUnique) String() string {
+	return "Unique(" + u.key.String() + ")"
+}
+
+// itemKey describes how to get the key of an array or slice item, as
+// used by Unique, BagBy and SetBy operators: the item itself, one of
+// its struct fields or the result of a function.
+type itemKey struct {
+	field string        // struct field name, if not empty
+	fn    reflect.Value // key function, if valid
+}
+
+// newItemKey returns the itemKey corresponding to "by", a struct
+// field name or a key function. It panics with "usage" if "by" is
+// neither of them.
+func newItemKey(by interface{}, funcName, usage string) (k itemKey) {
+	switch by := by.(type) {
+	case string:
+		if by == "" {
+			panic(usage)
+		}
+		k.field = by
+
+	default:
+		vfn := reflect.ValueOf(by)
+		if vfn.Kind() != reflect.Func {
+			panic(usage)
+		}
+		if vfn.Type().NumIn() != 1 || vfn.Type().NumOut() != 1 {
+			panic(funcName +
+				"(KEY_FUNC): KEY_FUNC must take one argument and return one value")
+		}
+		k.fn = vfn
+	}
+	return
+}
+
+// get returns the key of "item". If an error occurs, it is located
+// at "td".
+func (k itemKey) get(ctx Context, item reflect.Value, td TestDeep) (reflect.Value, *Error) {
+	if !k.fn.IsValid() {
+		if k.field == "" {
+			return item, nil
+		}
+		return itemField(ctx, item, k.field, td)
+	}
+
+	argType := k.fn.Type().In(0)
+	item, ok := funcArg(item, argType)
+	if !ok {
+		if ctx.booleanError {
+			return item, booleanError
+		}
+		return item, &Error{
+			Context:  ctx,
+			Message:  "incompatible parameter type",
+			Got:      rawString(item.Type().String()),
+			Expected: rawString(argType.String()),
+			Location: td.GetLocation(),
+		}
+	}
+
+	// Refuse to override unexported fields access, as Code does
+	if !item.CanInterface() {
+		if ctx.booleanError {
+			return item, booleanError
+		}
+		return item, &Error{
+			Context:  ctx,
+			Message:  "cannot compare unexported field",
+			Summary:  rawString("use Code() on surrounding struct instead"),
+			Location: td.GetLocation(),
+		}
+	}
+
+	if !ctx.userCodeAllowed() {
+		return item, booleanError
+	}
+	return k.fn.Call([]reflect.Value{item})[0], nil
+}
+
+// String returns the Go representation of the field name or the type
+// of the key function, or an empty string if the item itself is the
+// key.
+func (k itemKey) String() string {
+	switch {
+	case k.fn.IsValid():
+		return k.fn.Type().String()
+	case k.field != "":
+		return strconv.Quote(k.field)
+	}
+	return ""
+}
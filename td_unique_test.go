@@ -0,0 +1,154 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/maxatome/go-testdeep"
+)
+
+func TestUnique(t *testing.T) {
+	type MyArray [3]int
+
+	checkOK(t, []int{}, Unique())
+	checkOK(t, []int{1}, Unique())
+	checkOK(t, []int{3, 1, 2}, Unique())
+	checkOK(t, &MyArray{3, 1, 2}, Unique(nil))
+	checkOK(t, []interface{}{1, "1", nil}, Unique())
+	checkOK(t, [][]int{{1}, {1, 2}}, Unique())
+
+	checkError(t, []int{1, 2, 1, 3, 2, 1}, Unique(),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Duplicate indexes: 0, 2, 5\n  Duplicate value: (int) 1"),
+		})
+	checkError(t, [][]int{{1, 2}, {1, 2}}, Unique(),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("Duplicate indexes: 0, 1\n"),
+		})
+
+	big := make([]int, 10000)
+	for i := range big {
+		big[i] = i
+	}
+	checkOK(t, big, Unique())
+	big[9999] = 5000
+	checkError(t, big, Unique(),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Duplicate indexes: 5000, 9999\n  Duplicate value: (int) 5000"),
+		})
+
+	//
+	// Field
+	persons := []sortedPerson{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 42},
+		{Name: "Bob", Age: 20},
+	}
+	checkOK(t, persons, Unique())
+	checkOK(t, persons, Unique("Age"))
+	checkOK(t, []*sortedPerson{&persons[0], &persons[1]}, Unique("Name"))
+
+	checkError(t, persons, Unique("Name"),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Duplicate indexes: 1, 2\n" +
+				`  Duplicate value: (string) (len=3) "Bob"`),
+		})
+	// Scalar and non-scalar keys mixed: the first duplicate is reported
+	type uniqueInt struct{ ID int }
+	type uniqueInt64 struct{ ID int64 }
+	type uniqueSlice struct{ ID []int }
+	checkOK(t, []interface{}{uniqueInt{1}, uniqueInt64{1}, uniqueSlice{[]int{1}}},
+		Unique("ID"))
+	checkError(t,
+		[]interface{}{
+			uniqueInt{1}, uniqueSlice{[]int{1}}, uniqueInt{2},
+			uniqueSlice{[]int{1}}, uniqueInt{1},
+		},
+		Unique("ID"),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Duplicate indexes: 0, 4\n  Duplicate value: (int) 1"),
+		})
+	checkError(t,
+		[]interface{}{
+			uniqueSlice{[]int{1}}, uniqueInt{1}, uniqueSlice{[]int{1}},
+			uniqueInt{1},
+		},
+		Unique("ID"),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustContain("Duplicate indexes: 0, 2\n"),
+		})
+
+	checkError(t, persons, Unique("Unknown"),
+		expectedError{
+			Message:  mustBe("field not found"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("testdeep_test.sortedPerson"),
+			Expected: mustBe("struct with field Unknown"),
+		})
+
+	//
+	// Key function
+	checkOK(t, []string{"a", "B", "c"}, Unique(strings.ToLower))
+	checkOK(t, []interface{}{"a", "B"}, Unique(strings.ToLower))
+
+	checkError(t, []string{"a", "B", "b"}, Unique(strings.ToLower),
+		expectedError{
+			Message: mustBe("duplicate items"),
+			Path:    mustBe("DATA"),
+			Summary: mustBe("Duplicate indexes: 1, 2\n" +
+				`  Duplicate value: (string) (len=1) "b"`),
+		})
+	checkError(t, []int{1, 2}, Unique(strings.ToLower),
+		expectedError{
+			Message:  mustBe("incompatible parameter type"),
+			Path:     mustBe("DATA[0]"),
+			Got:      mustBe("int"),
+			Expected: mustBe("string"),
+		})
+
+	//
+	// Bad types
+	checkError(t, 12, Unique(),
+		expectedError{
+			Message:  mustBe("bad type"),
+			Path:     mustBe("DATA"),
+			Got:      mustBe("int"),
+			Expected: mustBe("Slice OR Array OR *Slice OR *Array"),
+		})
+
+	//
+	// Bad usage
+	checkPanic(t, func() { Unique("") }, "usage: Unique(")
+	checkPanic(t, func() { Unique(12) }, "usage: Unique(")
+	checkPanic(t, func() { Unique("a", "b") }, "usage: Unique(")
+	checkPanic(t, func() { Unique(strings.Repeat) },
+		"KEY_FUNC must take one argument and return one value")
+
+	//
+	// String
+	equalStr(t, Unique().String(), "Unique()")
+	equalStr(t, Unique("Name").String(), `Unique("Name")`)
+	equalStr(t, Unique(strings.ToLower).String(), "Unique(func(string) string)")
+}
+
+func TestUniqueTypeBehind(t *testing.T) {
+	equalTypes(t, Unique(), nil)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"time"
+)
+
+// timeAsInstant returns true if values of type "typ" have to be
+// compared as time instants, according to c config.
+func (c Context) timeAsInstant(typ reflect.Type) bool {
+	config := c.getConfig()
+	return (config.IgnoreTimeLocation || config.TimeLocation != nil) &&
+		typ.ConvertibleTo(timeType)
+}
+
+// timeString returns "t" as displayed in errors. "t" is first
+// converted to the location of c config if any, else to "loc" if not
+// nil.
+func (c Context) timeString(t time.Time, loc *time.Location) string {
+	if configLoc := c.getConfig().TimeLocation; configLoc != nil {
+		loc = configLoc
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.String()
+}
+
+// deepValueEqualTime compares "got" and "expected", both of the same
+// type convertible to time.Time, as instants: their location and
+// monotonic clock reading are ignored.
+func deepValueEqualTime(ctx Context, got, expected reflect.Value) *Error {
+	mustConvert := got.Type() != timeType
+
+	gotTime, err := getTime(ctx, got, mustConvert)
+	if err != nil {
+		return err
+	}
+	expectedTime, err := getTime(ctx, expected, mustConvert)
+	if err != nil {
+		return err
+	}
+
+	if gotTime.Equal(expectedTime) {
+		return nil
+	}
+
+	if ctx.booleanError {
+		return booleanError
+	}
+	return &Error{
+		Context: ctx,
+		Message: "values differ",
+		Got: rawString(ctx.timeString(gotTime, expectedTime.Location()) +
+			"\n(" + timeOffset(gotTime, expectedTime) + ")"),
+		Expected: rawString(ctx.timeString(expectedTime, nil)),
+	}
+}
+
+// timeOffset returns the offset of "got" relative to "ref" in a
+// readable way, as "1h30m0s after" or "2s before".
+func timeOffset(got, ref time.Time) string {
+	diff := got.Sub(ref)
+	switch {
+	case diff > 0:
+		return diff.String() + " after"
+	case diff < 0:
+		return (-diff).String() + " before"
+	default:
+		return "same instant"
+	}
+}
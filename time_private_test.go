@@ -0,0 +1,123 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeLocation(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+	}
+
+	paris := time.FixedZone("CET", 3600)
+	date := time.Date(2018, time.March, 9, 1, 2, 3, 0, time.UTC)
+
+	got := []event{{Name: "start", At: date.In(paris)}}
+	expected := []event{{Name: "start", At: date}}
+
+	check := func(config ContextConfig, got, expected interface{}) *Error {
+		t.Helper()
+
+		ctx := NewContextWithConfig("DATA", config)
+		vgot, vexpected := reflect.ValueOf(got), reflect.ValueOf(expected)
+
+		err := deepValueEqual(ctx, vgot, vexpected)
+		if (deepValueEqual(ctx.boolean(), vgot, vexpected) == nil) != (err == nil) {
+			t.Errorf("boolean and non-boolean contexts disagree: %v", err)
+		}
+		return err
+	}
+
+	if check(ContextConfig{}, got, expected) == nil {
+		t.Error("times in different locations should differ by default")
+	}
+
+	if err := check(ContextConfig{IgnoreTimeLocation: true}, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := check(ContextConfig{TimeLocation: paris}, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Monotonic clock reading is ignored too
+	now := time.Now()
+	if err := check(ContextConfig{IgnoreTimeLocation: true}, now, now.Round(0)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Types convertible to time.Time
+	type myTime time.Time
+	if err := check(ContextConfig{IgnoreTimeLocation: true},
+		myTime(date.In(paris)), myTime(date)); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Errors
+	expected[0].At = date.Add(time.Hour)
+	err := check(ContextConfig{IgnoreTimeLocation: true}, got, expected)
+	if err == nil {
+		t.Error("times should differ")
+	} else {
+		equalStr(t, err.Context.Path(), "DATA[0].At")
+		equalStr(t, err.Message, "values differ")
+		equalStr(t, string(err.Got.(rawString)),
+			"2018-03-09 01:02:03 +0000 UTC\n(1h0m0s before)")
+		equalStr(t, string(err.Expected.(rawString)), "2018-03-09 02:02:03 +0000 UTC")
+	}
+
+	err = check(ContextConfig{TimeLocation: paris}, got, expected)
+	if err == nil {
+		t.Error("times should differ")
+	} else {
+		equalStr(t, string(err.Got.(rawString)),
+			"2018-03-09 02:02:03 +0100 CET\n(1h0m0s before)")
+		equalStr(t, string(err.Expected.(rawString)), "2018-03-09 03:02:03 +0100 CET")
+	}
+
+	// Used by time operators to display times
+	err = check(ContextConfig{TimeLocation: paris}, date, Before(date))
+	if err == nil {
+		t.Error("Before should fail")
+	} else {
+		equalStr(t, string(err.Got.(rawString)),
+			"2018-03-09 02:02:03 +0100 CET\n(same instant)")
+		equalStr(t, string(err.Expected.(rawString)),
+			"before 2018-03-09 02:02:03 +0100 CET")
+	}
+}
+
+func TestRecentNow(t *testing.T) {
+	ctx := NewContext("DATA")
+	ctx.cmp = &cmpState{prePass: true}
+
+	recent := reflect.ValueOf(Recent(time.Minute))
+	if err := deepValueEqual(ctx.boolean(), reflect.ValueOf(time.Now()), recent); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ctx.cmp.now.IsZero() {
+		t.Fatal("the time of the comparison should have been captured")
+	}
+
+	// The full pass reuses the time captured during the pre-pass
+	ctx.cmp.prePass = false
+	ctx.cmp.now = ctx.cmp.now.Add(-time.Hour)
+	past := ctx.cmp.now
+	if deepValueEqual(ctx, reflect.ValueOf(time.Now()), recent) == nil {
+		t.Error("time.Now() should not be within 1m of the captured time")
+	}
+	if err := deepValueEqual(ctx, reflect.ValueOf(past), recent); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !ctx.cmp.now.Equal(past) {
+		t.Error("the captured time should not change")
+	}
+}
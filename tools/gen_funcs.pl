@@ -26,10 +26,24 @@ EOH
 # These functions are variadics, but only with one possible param. In
 # this case, discard the variadic property and use a default value for
 # this optional parameter.
-my %IGNORE_VARIADIC = (Between   => 'BoundsInIn',
-		       N         => 0,
-		       Re        => 'nil',
-		       TruncTime => 0);
+my %IGNORE_VARIADIC = (Between    => 'BoundsInIn',
+		       N          => 0,
+		       Re         => 'nil',
+		       RoundTime  => 0,
+		       StructLike => '""',
+		       TruncTime  => 0,
+		       Unique     => 'nil');
+
+# The optional parameter of these functions is not available in their
+# Cmp* shortcut, so the shortcut signature does not change with it.
+my %DROP_VARIADIC = (Array        => 1,
+		     Slice        => 1,
+		     SuperSliceOf => 1);
+
+# These operators have no Cmp* shortcut: Ignore always succeeds and
+# Times can only be used inside Bag & co.
+my %NO_SHORTCUT = (Ignore => 1,
+		   Times  => 1);
 
 my $dir = shift;
 
@@ -47,7 +61,7 @@ while (readdir $dh)
             if ($line =~ /^func ([A-Z]\w*)\((.*?)\) TestDeep \{$/)
             {
 		my $func = $1;
-		if ($func ne 'Ignore')
+		unless ($NO_SHORTCUT{$func})
 		{
 		    my @args;
 		    foreach my $arg (split(/, /, $2))
@@ -56,6 +70,11 @@ while (readdir $dh)
 			@arg{qw(name type)} = split(/ /, $arg, 2);
 			if ($arg{variadic} = $arg{type} =~ s/^\.{3}//)
 			{
+			    if ($DROP_VARIADIC{$func})
+			    {
+				$funcs{$func}{dropped} = $arg{name};
+				last;
+			    }
 			    if (exists $IGNORE_VARIADIC{$func})
 			    {
 				$arg{default} = $IGNORE_VARIADIC{$func};
@@ -144,6 +163,15 @@ EOF
 EOF
     }
 
+    elsif (exists $funcs{$func}{dropped})
+    {
+	$func_comment .= <<EOF;
+//
+// $func() optional parameter "$funcs{$func}{dropped}" is not available
+// here. Use CmpDeeply() with $func() to pass it.
+EOF
+    }
+
     $func_comment .= <<EOF;
 //
 // Returns true if the test is OK, false if it fails.
@@ -246,6 +274,11 @@ foreach my $func (sort keys %funcs)
 	foreach my $info ([ "Cmp$func(t, ", "Cmp$func", \$funcs_test_contents ],
 			  [ "t.$func(",     "T_$func",  \$t_test_contents ])
 	{
+	    # Examples using a dropped parameter cannot use the shortcut
+	    next if exists $funcs{$func}{dropped}
+		and $example->{code} =~ /CmpDeeply\(t,\s+\S+,\s+$func($rep)/
+		and extract_params("$func$name", $1) > @$args;
+
 	    (my $code = $example->{code}) =~
 		s%CmpDeeply\(t,\s+(\S+),\s+$func($rep)%
                   my @params = extract_params("$func$name", $2);
@@ -0,0 +1,169 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// treeChild is an expected value nested in a TestDeep operator.
+type treeChild struct {
+	name  string // how the value is accessed from its parent, can be ""
+	value reflect.Value
+}
+
+// treeParent is implemented by TestDeep operators having nested
+// expected values, so they can be displayed in the expected tree.
+type treeParent interface {
+	treeChildren() []treeChild
+}
+
+// treeNode is a TestDeep operator in the expected tree.
+type treeNode struct {
+	name     string
+	op       TestDeep
+	children []*treeNode
+}
+
+// buildTree returns the nodes of all TestDeep operators found in
+// "val", "val" included.
+func buildTree(name string, val reflect.Value,
+	seen map[uintptr]bool) (nodes []*treeNode) {
+	if !val.IsValid() {
+		return nil
+	}
+
+	if val.Type().Implements(testDeeper) {
+		if val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return nil
+			}
+		}
+
+		iface, ok := getInterface(val, true)
+		if !ok {
+			return nil
+		}
+
+		node := &treeNode{
+			name: name,
+			op:   iface.(TestDeep),
+		}
+		if parent, ok := node.op.(treeParent); ok {
+			for _, child := range parent.treeChildren() {
+				node.children = append(node.children,
+					buildTree(child.name, child.value, seen)...)
+			}
+		}
+		return []*treeNode{node}
+	}
+
+	switch val.Kind() {
+	case reflect.Interface:
+		return buildTree(name, val.Elem(), seen)
+
+	case reflect.Ptr:
+		if val.IsNil() || seen[val.Pointer()] {
+			return nil
+		}
+		seen[val.Pointer()] = true
+		return buildTree(name, val.Elem(), seen)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			nodes = append(nodes,
+				buildTree(fmt.Sprintf("%s[%d]", name, i), val.Index(i), seen)...)
+		}
+
+	case reflect.Map:
+		// Sort keys, as dumper does, so the tree is always the same
+		keys := val.MapKeys()
+		names := make([]string, len(keys))
+		order := make([]int, len(keys))
+		for i, key := range keys {
+			names[i] = toString(key)
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			return names[order[i]] < names[order[j]]
+		})
+
+		for _, i := range order {
+			nodes = append(nodes,
+				buildTree(name+"["+names[i]+"]", val.MapIndex(keys[i]), seen)...)
+		}
+
+	case reflect.Struct:
+		typ := val.Type()
+		for i, n := 0, val.NumField(); i < n; i++ {
+			nodes = append(nodes,
+				buildTree(name+"."+typ.Field(i).Name, val.Field(i), seen)...)
+		}
+	}
+	return
+}
+
+// expectedTree returns the tree of TestDeep operators contained in
+// "expected", marking the branch leading to the operator responsible
+// of "err". If "expected" does not contain any operator, the empty
+// string is returned.
+func expectedTree(expected reflect.Value, err *Error) string {
+	nodes := buildTree("", expected, map[uintptr]bool{})
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	failing := map[TestDeep]bool{}
+	for ; err != nil; err = err.Origin {
+		for _, op := range err.Context.operators {
+			failing[op] = true
+		}
+	}
+
+	buf := bytes.NewBufferString("\nExpected tree (✗ marks the failing branch):")
+	for _, node := range nodes {
+		node.toBuffer(buf, 0, failing)
+	}
+	return buf.String()
+}
+
+func (n *treeNode) toBuffer(buf *bytes.Buffer, depth int,
+	failing map[TestDeep]bool) {
+	buf.WriteString("\n\t")
+	buf.WriteString(strings.Repeat("  ", depth))
+	buf.WriteString(ternStr(failing[n.op], "✗ ", "  "))
+
+	if n.name != "" {
+		buf.WriteString(n.name)
+		buf.WriteString(": ")
+	}
+	buf.WriteString(operatorLabel(n.op))
+
+	if loc := n.op.GetLocation(); loc.IsInitialized() {
+		buf.WriteString("  [")
+		buf.WriteString(loc.String())
+		buf.WriteByte(']')
+	}
+
+	for _, child := range n.children {
+		child.toBuffer(buf, depth+1, failing)
+	}
+}
+
+// operatorLabel returns the String() of "op" reduced to its first
+// line.
+func operatorLabel(op TestDeep) string {
+	str := op.String()
+	if eol := strings.IndexByte(str, '\n'); eol >= 0 {
+		return str[:eol] + "…"
+	}
+	return str
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestExpectedTree(t *testing.T) {
+	type tree struct {
+		Name  string
+		Num   int
+		Items []interface{}
+	}
+
+	locRe := regexp.MustCompile(` at tree_private_test\.go:\d+\]`)
+
+	check := func(got, expected interface{}, expectedTreeStr string) {
+		t.Helper()
+
+		ctx := NewContextWithConfig("DATA", ContextConfig{ShowExpectedTree: true})
+		err := deepValueEqual(ctx, reflect.ValueOf(got), reflect.ValueOf(expected))
+		if err == nil {
+			t.Error("an error was expected")
+			return
+		}
+
+		equalStr(t,
+			locRe.ReplaceAllString(expectedTree(reflect.ValueOf(expected), err),
+				"]"),
+			expectedTreeStr)
+	}
+
+	check(tree{Name: "Bob", Num: 12},
+		All(
+			Isa(tree{}),
+			Struct(tree{}, StructFields{
+				"Name": Re(`^B`),
+				"Num":  Any(1, Between(3, 5)),
+			})),
+		`
+Expected tree (✗ marks the failing branch):
+	✗ All(testdeep.tree,…  [All]
+	    testdeep.tree  [Isa]
+	  ✗ Struct(testdeep.tree{…  [Struct]
+	      .Name: ^B  [Re]
+	    ✗ .Num: Any((int) 1,…  [Any]
+	        3 ≤ got ≤ 5  [Between]`)
+
+	// Operators nested in non-operator values
+	check(tree{Items: []interface{}{1, 2}},
+		tree{Items: []interface{}{1, Not(2)}},
+		`
+Expected tree (✗ marks the failing branch):
+	✗ .Items[1]: Not((int) 2)  [Not]`)
+
+	// Map keys are sorted
+	check(map[string]interface{}{"a": 0, "b": 0, "c": 0, "d": 0},
+		map[string]interface{}{"d": Lt(1), "b": Lt(1), "c": Gt(1), "a": Lt(1)},
+		`
+Expected tree (✗ marks the failing branch):
+	  [(string) (len=1) "a"]: < 1  [Lt]
+	  [(string) (len=1) "b"]: < 1  [Lt]
+	✗ [(string) (len=1) "c"]: > 1  [Gt]
+	  [(string) (len=1) "d"]: < 1  [Lt]`)
+
+	// Invalid got value
+	check(nil, Ptr(12),
+		`
+Expected tree (✗ marks the failing branch):
+	✗ *int  [Ptr]`)
+
+	// No operators at all
+	equalStr(t, expectedTree(reflect.ValueOf(12), &Error{}), "")
+
+	// Not filled when ShowExpectedTree is false
+	err := deepValueEqual(NewContext("DATA"),
+		reflect.ValueOf(12), reflect.ValueOf(Between(1, 3)))
+	if err == nil || err.Context.operators != nil {
+		t.Errorf("operators should not be recorded: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode/utf8"
+)
+
+// limit truncates str, the rendering of a value, according to c
+// limits. If c.DumpTruncated is true and str has been truncated, the
+// original str is dumped in a temporary file whose path is appended
+// to the returned string.
+func (c *ContextConfig) limit(str string) string {
+	if !c.hasLimits() {
+		return str
+	}
+
+	res := str
+
+	if c.MaxItems > 0 || c.MaxDepth > 0 {
+		res = strings.Join(c.limitLines(strings.Split(str, "\n"), 0), "\n")
+	}
+
+	if c.MaxOutputLen > 0 && len(res) > c.MaxOutputLen {
+		cut := c.MaxOutputLen
+		for cut > 0 && !utf8.RuneStart(res[cut]) {
+			cut--
+		}
+		res = fmt.Sprintf("%s…(%d more bytes)", res[:cut], len(res)-cut)
+	}
+
+	if c.DumpTruncated && res != str {
+		if path, err := dumpToTempFile(str); err == nil {
+			res += "\n…(full value dumped to " + path + ")"
+		}
+	}
+	return res
+}
+
+// limitLines applies MaxItems and MaxDepth limits to lines. Each line
+// ending with "{" opens a block, its contents being the following
+// lines more indented than it. Each entry of a block starts at the
+// indentation of the first line of the block contents.
+func (c *ContextConfig) limitLines(lines []string, depth int) []string {
+	out := make([]string, 0, len(lines))
+
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+		out = append(out, line)
+
+		if !strings.HasSuffix(strings.TrimRight(line, " "), "{") {
+			continue
+		}
+
+		indent := indentOf(line)
+		end := idx + 1
+		for end < len(lines) && indentOf(lines[end]) > indent {
+			end++
+		}
+
+		contents := lines[idx+1 : end]
+		idx = end - 1
+		if len(contents) == 0 {
+			continue
+		}
+
+		entries := splitEntries(contents)
+		entriesIndent := strings.Repeat(" ", indentOf(contents[0]))
+
+		if c.MaxDepth > 0 && depth >= c.MaxDepth {
+			out = append(out, entriesIndent+moreItems(len(entries)))
+			continue
+		}
+
+		keep := len(entries)
+		if c.MaxItems > 0 && keep > c.MaxItems {
+			keep = c.MaxItems
+		}
+		for _, entry := range entries[:keep] {
+			out = append(out, c.limitLines(entry, depth+1)...)
+		}
+		if keep < len(entries) {
+			out = append(out, entriesIndent+moreItems(len(entries)-keep))
+		}
+	}
+	return out
+}
+
+// splitEntries splits the lines of a block contents into entries.
+func splitEntries(lines []string) (entries [][]string) {
+	entryIndent := indentOf(lines[0])
+	start := 0
+
+	for idx := 1; idx < len(lines); idx++ {
+		line := lines[idx]
+		if indentOf(line) == entryIndent && len(line) > entryIndent &&
+			strings.IndexByte("})]", line[entryIndent]) < 0 {
+			entries = append(entries, lines[start:idx])
+			start = idx
+		}
+	}
+	return append(entries, lines[start:])
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func moreItems(num int) string {
+	if num == 1 {
+		return "…(1 more item)"
+	}
+	return fmt.Sprintf("…(%d more items)", num)
+}
+
+// dumpToTempFile writes str in a new temporary file and returns its
+// path.
+func dumpToTempFile(str string) (string, error) {
+	f, err := ioutil.TempFile("", "testdeep-")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.WriteString(str)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
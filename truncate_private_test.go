@@ -0,0 +1,123 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLimit(t *testing.T) {
+	const value = `([]int) (len=4 cap=4) {
+ (int) 1,
+ (map[string]int) (len=2) {
+  (string) (len=1) "a": (int) 1,
+  (string) (len=1) "b": (int) 2
+ },
+ (int) 3,
+ (int) 4
+}`
+
+	config := ContextConfig{}
+	equalStr(t, config.limit(value), value)
+
+	config = ContextConfig{MaxItems: 10}
+	equalStr(t, config.limit(value), value)
+
+	config = ContextConfig{MaxItems: 2}
+	equalStr(t, config.limit(value), `([]int) (len=4 cap=4) {
+ (int) 1,
+ (map[string]int) (len=2) {
+  (string) (len=1) "a": (int) 1,
+  (string) (len=1) "b": (int) 2
+ },
+ …(2 more items)
+}`)
+
+	config = ContextConfig{MaxItems: 1}
+	equalStr(t, config.limit(value), `([]int) (len=4 cap=4) {
+ (int) 1,
+ …(3 more items)
+}`)
+
+	config = ContextConfig{MaxDepth: 1}
+	equalStr(t, config.limit(value), `([]int) (len=4 cap=4) {
+ (int) 1,
+ (map[string]int) (len=2) {
+  …(2 more items)
+ },
+ (int) 3,
+ (int) 4
+}`)
+
+	config = ContextConfig{MaxDepth: 1, MaxItems: 3}
+	equalStr(t, config.limit(value), `([]int) (len=4 cap=4) {
+ (int) 1,
+ (map[string]int) (len=2) {
+  …(2 more items)
+ },
+ (int) 3,
+ …(1 more item)
+}`)
+
+	config = ContextConfig{MaxOutputLen: 10}
+	equalStr(t, config.limit(value), "([]int) (l…(141 more bytes)")
+
+	// Do not cut a rune
+	config = ContextConfig{MaxOutputLen: 2}
+	equalStr(t, config.limit("a€b"), "a…(4 more bytes)")
+
+	// Dump the full value in a temporary file
+	config = ContextConfig{MaxOutputLen: 10, DumpTruncated: true}
+	str := config.limit(value)
+	const dumpedTo = "\n…(full value dumped to "
+	pos := strings.Index(str, dumpedTo)
+	if pos < 0 || !strings.HasSuffix(str, ")") {
+		t.Fatalf("no dump file found in `%s'", str)
+	}
+	path := str[pos+len(dumpedTo) : len(str)-1]
+	defer os.Remove(path) // nolint: errcheck
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Cannot read dump file: %s", err)
+	}
+	equalStr(t, string(contents), value)
+
+	// Nothing truncated, so nothing dumped
+	equalStr(t, config.limit("short"), "short")
+}
+
+func TestEnvConfig(t *testing.T) {
+	const name = "TESTDEEP_TEST_ENV_CONFIG"
+
+	defer os.Unsetenv(name) // nolint: errcheck
+
+	os.Setenv(name, "12") // nolint: errcheck
+	if getEnvInt(name) != 12 {
+		t.Errorf("getEnvInt() should return 12, not %d", getEnvInt(name))
+	}
+
+	for _, bad := range []string{"", "-3", "foo"} {
+		os.Setenv(name, bad) // nolint: errcheck
+		if getEnvInt(name) != 0 {
+			t.Errorf("getEnvInt(%q) should return 0, not %d", bad, getEnvInt(name))
+		}
+	}
+
+	os.Setenv(name, "true") // nolint: errcheck
+	if !getEnvBool(name) {
+		t.Error("getEnvBool() should return true")
+	}
+
+	os.Setenv(name, "foo") // nolint: errcheck
+	if getEnvBool(name) {
+		t.Error("getEnvBool() should return false")
+	}
+}
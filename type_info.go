@@ -0,0 +1,185 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// structField describes a field of a struct type.
+type structField struct {
+	name      string
+	typ       reflect.Type
+	index     []int
+	tag       reflect.StructTag
+	anonymous bool
+	exported  bool
+	// owner is the struct type declaring the field, which differs
+	// from the struct one for promoted fields
+	owner reflect.Type
+}
+
+// typeInfo holds the metadata of a type needed by testdeep, computed
+// once per type. See getTypeInfo function.
+type typeInfo struct {
+	typ  reflect.Type
+	zero reflect.Value
+	// true if nil is a valid value of typ
+	nilable bool
+	// order compares two values of typ using its order method, nil if
+	// typ has none. See getOrderFunc
+	order orderFunc
+
+	// Following fields are only filled for struct types
+
+	// fields are the direct fields of the struct, in their declaration
+	// order
+	fields []structField
+	// allExported is true if all direct fields are exported
+	allExported bool
+	// visibleFields are all the fields accessible by name, including
+	// the promoted ones of embedded structs, sorted by name
+	visibleFields []structField
+	fieldsByName  map[string]int // index in visibleFields
+}
+
+var typeInfos = struct {
+	sync.RWMutex
+	cache map[reflect.Type]*typeInfo
+}{
+	cache: map[reflect.Type]*typeInfo{},
+}
+
+// getTypeInfo returns the metadata of "typ". It is safe for
+// concurrent use.
+func getTypeInfo(typ reflect.Type) *typeInfo {
+	typeInfos.RLock()
+	info := typeInfos.cache[typ]
+	typeInfos.RUnlock()
+
+	if info != nil {
+		return info
+	}
+
+	info = newTypeInfo(typ)
+
+	typeInfos.Lock()
+	// Another goroutine may have done the job in the meantime
+	if prevInfo := typeInfos.cache[typ]; prevInfo != nil {
+		info = prevInfo
+	} else {
+		typeInfos.cache[typ] = info
+	}
+	typeInfos.Unlock()
+
+	return info
+}
+
+func newTypeInfo(typ reflect.Type) *typeInfo {
+	info := &typeInfo{
+		typ:   typ,
+		zero:  reflect.Zero(typ),
+		order: newOrderFunc(typ),
+	}
+
+	switch typ.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.Slice:
+		info.nilable = true
+
+	case reflect.Struct:
+		info.allExported = true
+		info.fields = make([]structField, typ.NumField())
+		for i := range info.fields {
+			info.fields[i] = newStructField(typ, typ.Field(i))
+			if !info.fields[i].exported {
+				info.allExported = false
+			}
+		}
+
+		// Get all field names, including promoted ones. As the function
+		// never matches, shadowed names can be visited several times
+		names := map[string]bool{}
+		typ.FieldByNameFunc(func(name string) bool {
+			names[name] = true
+			return false
+		})
+
+		info.visibleFields = make([]structField, 0, len(names))
+		for name := range names {
+			// Ambiguous names are not found
+			if field, found := typ.FieldByName(name); found {
+				owner := typ
+				if len(field.Index) > 1 {
+					owner = typ.FieldByIndex(field.Index[:len(field.Index)-1]).Type
+					if owner.Kind() == reflect.Ptr {
+						owner = owner.Elem()
+					}
+				}
+				info.visibleFields = append(info.visibleFields,
+					newStructField(owner, field))
+			}
+		}
+		sort.Slice(info.visibleFields, func(i, j int) bool {
+			return info.visibleFields[i].name < info.visibleFields[j].name
+		})
+
+		info.fieldsByName = make(map[string]int, len(info.visibleFields))
+		for idx, field := range info.visibleFields {
+			info.fieldsByName[field.name] = idx
+		}
+	}
+
+	return info
+}
+
+func newStructField(owner reflect.Type, field reflect.StructField) structField {
+	return structField{
+		owner:     owner,
+		name:      field.Name,
+		typ:       field.Type,
+		index:     field.Index,
+		tag:       field.Tag,
+		anonymous: field.Anonymous,
+		exported:  field.PkgPath == "",
+	}
+}
+
+// fieldByName returns the field named "name", including promoted
+// ones, as reflect.Type.FieldByName does.
+func (i *typeInfo) fieldByName(name string) (*structField, bool) {
+	idx, ok := i.fieldsByName[name]
+	if !ok {
+		return nil, false
+	}
+	return &i.visibleFields[idx], true
+}
+
+// nilValue returns the typed nil value of "typ", and false if "typ"
+// cannot be nil.
+func nilValue(typ reflect.Type) (reflect.Value, bool) {
+	info := getTypeInfo(typ)
+	return info.zero, info.nilable
+}
+
+// closestFieldName returns the name of the visible field the closest
+// to "name", or "" if none is close enough.
+func (i *typeInfo) closestFieldName(name string) string {
+	closest, minDist := "", len(name)/2+1
+	for _, field := range i.visibleFields {
+		if strings.EqualFold(field.name, name) {
+			return field.name
+		}
+		if dist := levenshtein(field.name, name); dist < minDist {
+			closest, minDist = field.name, dist
+		}
+	}
+	return closest
+}
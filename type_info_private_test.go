@@ -0,0 +1,112 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type typeInfoEmbedded struct {
+	Name  string
+	Dup   int
+	inner int
+}
+
+type typeInfoOther struct {
+	Dup int
+}
+
+type typeInfoStruct struct {
+	typeInfoEmbedded
+	typeInfoOther
+	Num  int
+	Name []string // shadows typeInfoEmbedded.Name
+	priv bool
+}
+
+func TestTypeInfo(t *testing.T) {
+	typ := reflect.TypeOf(typeInfoStruct{})
+
+	// Concurrent use always returns the same instance
+	var wg sync.WaitGroup
+	infos := make([]*typeInfo, 10)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i] = getTypeInfo(typ)
+		}(i)
+	}
+	wg.Wait()
+	for i, info := range infos {
+		if info != infos[0] {
+			t.Errorf("getTypeInfo #%d returned another instance", i)
+		}
+	}
+
+	info := infos[0]
+	if info.typ != typ {
+		t.Errorf("bad type: %s", info.typ)
+	}
+	if info.nilable {
+		t.Error("a struct cannot be nil")
+	}
+	if info.allExported {
+		t.Error("priv field is not exported")
+	}
+
+	var names []string
+	for _, field := range info.fields {
+		names = append(names, field.name)
+	}
+	if !reflect.DeepEqual(names, []string{
+		"typeInfoEmbedded", "typeInfoOther", "Num", "Name", "priv",
+	}) {
+		t.Errorf("bad direct fields: %v", names)
+	}
+
+	// Dup is ambiguous, so not visible
+	names = names[:0]
+	for _, field := range info.visibleFields {
+		names = append(names, field.name)
+	}
+	if !reflect.DeepEqual(names, []string{
+		"Name", "Num", "inner", "priv", "typeInfoEmbedded", "typeInfoOther",
+	}) {
+		t.Errorf("bad visible fields: %v", names)
+	}
+
+	field, ok := info.fieldByName("Name")
+	if !ok || field.typ != reflect.TypeOf([]string{}) ||
+		!reflect.DeepEqual(field.index, []int{3}) || !field.exported {
+		t.Errorf("bad Name field: %+v", field)
+	}
+
+	field, ok = info.fieldByName("inner")
+	if !ok || !reflect.DeepEqual(field.index, []int{0, 2}) || field.exported {
+		t.Errorf("bad inner field: %+v", field)
+	}
+
+	if _, ok = info.fieldByName("Dup"); ok {
+		t.Error("Dup field should not be found")
+	}
+
+	if getTypeInfo(reflect.TypeOf(typeInfoOther{})).allExported != true {
+		t.Error("all typeInfoOther fields are exported")
+	}
+
+	// nilValue
+	val, ok := nilValue(reflect.TypeOf([]int{}))
+	if !ok || !val.IsNil() || val.Type() != reflect.TypeOf([]int{}) {
+		t.Errorf("bad nil value for []int: %v, %t", val, ok)
+	}
+	if _, ok = nilValue(typ); ok {
+		t.Error("a struct cannot be nil")
+	}
+}
@@ -15,10 +15,12 @@ import (
 )
 
 var (
-	testDeeper        = reflect.TypeOf((*TestDeep)(nil)).Elem()
-	stringerInterface = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
-	timeType          = reflect.TypeOf(time.Time{})
-	intType           = reflect.TypeOf(int(0))
+	testDeeper                = reflect.TypeOf((*TestDeep)(nil)).Elem()
+	testDeepStringerInterface = reflect.TypeOf((*testDeepStringer)(nil)).Elem()
+	stringerInterface         = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	timeType                  = reflect.TypeOf(time.Time{})
+	durationType              = reflect.TypeOf(time.Duration(0))
+	intType                   = reflect.TypeOf(int(0))
 )
 
 type testDeepStringer interface {
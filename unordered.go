@@ -0,0 +1,300 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// unorderedSlice returns true if the slice "c" is pointing to, of
+// type "typ", has to be compared without taking care of the order of
+// its items, according to c config.
+func (c Context) unorderedSlice(typ reflect.Type) bool {
+	config := c.getConfig()
+
+	if config.UnorderedSlices {
+		return true
+	}
+
+	elemType := typ.Elem()
+	for _, t := range config.UnorderedSliceTypes {
+		if elemType == t {
+			return true
+		}
+	}
+
+	if c.slicePaths != nil && c.slicePaths.reachable(c.jsonDepth) {
+		path := c.Segments().JSONPointer()
+		for _, matcher := range c.slicePaths.matchers {
+			if matcher(path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// keepPath returns true if the path has to be tracked even in a
+// boolean Context, as it is needed to take comparison decisions.
+func (c Context) keepPath() bool {
+	return c.slicePaths != nil && c.slicePaths.reachable(c.jsonDepth)
+}
+
+// slicePaths is the compiled form of
+// ContextConfig.UnorderedSlicePaths.
+type slicePaths struct {
+	patterns []string // copy of the compiled patterns
+	matchers []func(string) bool
+	// maximum JSON Pointer depth the patterns can match, -1 if unbounded
+	maxDepth int
+}
+
+// reachable returns true if a path of "depth" segments can be matched
+// by p.
+func (p *slicePaths) reachable(depth int) bool {
+	return p.maxDepth < 0 || depth <= p.maxDepth
+}
+
+var slicePathsCache = struct {
+	sync.RWMutex
+	cache map[*string]*slicePaths
+}{
+	cache: map[*string]*slicePaths{},
+}
+
+// getSlicePaths returns the compiled form of "patterns", nil if
+// empty. Patterns are compiled once per ContextConfig: the cache is
+// keyed by the backing array of "patterns", shared by the copies of a
+// config.
+func getSlicePaths(patterns []string) *slicePaths {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	key := &patterns[0]
+
+	slicePathsCache.RLock()
+	paths := slicePathsCache.cache[key]
+	slicePathsCache.RUnlock()
+
+	if paths != nil && equalStrings(paths.patterns, patterns) {
+		return paths
+	}
+
+	paths = &slicePaths{
+		patterns: append([]string(nil), patterns...),
+		matchers: make([]func(string) bool, len(patterns)),
+	}
+	for idx, pattern := range patterns {
+		paths.matchers[idx] = getFieldMatcher(pattern)
+
+		// Glob "*" never matches "/", contrary to regexps
+		depth := -1
+		if !strings.HasPrefix(pattern, "=~") {
+			depth = strings.Count(pattern, "/")
+		}
+		if idx == 0 || paths.maxDepth >= 0 && (depth < 0 || depth > paths.maxDepth) {
+			paths.maxDepth = depth
+		}
+	}
+
+	slicePathsCache.Lock()
+	slicePathsCache.cache[key] = paths
+	slicePathsCache.Unlock()
+
+	return paths
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Values of unorderedMatcher.equal cache.
+const (
+	pairUnknown int8 = iota
+	pairEqual
+	pairDiffer
+)
+
+// unorderedMatcher pairs expected items with got items, so that each
+// paired got item matches its expected item. It computes a maximum
+// bipartite matching using augmenting paths (Kuhn's algorithm), each
+// comparison being done at most once.
+type unorderedMatcher struct {
+	expectedLen int
+	gotLen      int
+	// match returns true if expected item "e" matches got item "g"
+	match func(e, g int) bool
+	// true if gotPair & expPair are seeded with pairs sharing the same
+	// index, all other same index pairs differing
+	seeded bool
+
+	equal   []int8 // expectedLen × gotLen cache, allocated on demand
+	gotPair []int  // expected item paired with each got item, or -1
+	expPair []int  // got item paired with each expected item, or -1
+	visited []bool // got items visited during current augmentation
+}
+
+func newUnorderedMatcher(expectedLen, gotLen int, match func(e, g int) bool) *unorderedMatcher {
+	m := unorderedMatcher{
+		expectedLen: expectedLen,
+		gotLen:      gotLen,
+		match:       match,
+		gotPair:     make([]int, gotLen),
+		expPair:     make([]int, expectedLen),
+	}
+	for g := range m.gotPair {
+		m.gotPair[g] = -1
+	}
+	for e := range m.expPair {
+		m.expPair[e] = -1
+	}
+	return &m
+}
+
+func (m *unorderedMatcher) isEqual(e, g int) bool {
+	if m.equal == nil {
+		m.equal = make([]int8, m.expectedLen*m.gotLen)
+		if m.seeded {
+			for idx, pair := range m.expPair {
+				if pair == idx {
+					m.equal[idx*m.gotLen+idx] = pairEqual
+				} else {
+					m.equal[idx*m.gotLen+idx] = pairDiffer
+				}
+			}
+		}
+	}
+
+	cell := &m.equal[e*m.gotLen+g]
+	if *cell == pairUnknown {
+		if m.match(e, g) {
+			*cell = pairEqual
+		} else {
+			*cell = pairDiffer
+		}
+	}
+	return *cell == pairEqual
+}
+
+// augment tries to pair expected item "e", possibly pairing again
+// already paired got items.
+func (m *unorderedMatcher) augment(e int) bool {
+	for g := 0; g < m.gotLen; g++ {
+		if m.visited[g] || !m.isEqual(e, g) {
+			continue
+		}
+		m.visited[g] = true
+
+		if m.gotPair[g] < 0 || m.augment(m.gotPair[g]) {
+			m.gotPair[g] = e
+			m.expPair[e] = g
+			return true
+		}
+	}
+	return false
+}
+
+// pair tries to pair expected item "e" with a got item, and returns
+// true if it succeeds.
+func (m *unorderedMatcher) pair(e int) bool {
+	if m.visited == nil {
+		m.visited = make([]bool, m.gotLen)
+	} else {
+		for g := range m.visited {
+			m.visited[g] = false
+		}
+	}
+	return m.augment(e)
+}
+
+// deepValueEqualUnordered compares "got" and "expected" slices, of
+// the same type and length, as multisets: each got item has to match
+// one expected item, whatever their indexes are.
+func deepValueEqualUnordered(ctx Context, got, expected reflect.Value) *Error {
+	bctx := ctx.boolean()
+	length := got.Len()
+	m := newUnorderedMatcher(length, length, func(e, g int) bool {
+		return deepValueEqual(bctx.AddArrayIndex(g),
+			got.Index(g), expected.Index(e)) == nil
+	})
+
+	// First, pair items sharing the same index, the most common
+	// case. These pairs can be undone later if needed
+	var unpaired []int
+	for idx := 0; idx < length; idx++ {
+		if m.match(idx, idx) {
+			m.gotPair[idx] = idx
+			m.expPair[idx] = idx
+		} else {
+			unpaired = append(unpaired, idx)
+		}
+	}
+
+	if len(unpaired) == 0 {
+		return nil
+	}
+	m.seeded = true
+
+	var missing []int
+	for _, e := range unpaired {
+		if !m.pair(e) {
+			if ctx.booleanError {
+				return booleanError
+			}
+			missing = append(missing, e)
+		}
+	}
+
+	if missing == nil {
+		return nil
+	}
+
+	var extra []int
+	for g, e := range m.gotPair {
+		if e < 0 {
+			extra = append(extra, g)
+		}
+	}
+
+	// Only one item differs: report the precise difference
+	if len(missing) == 1 {
+		return deepValueEqual(ctx.AddArrayIndex(extra[0]),
+			got.Index(extra[0]), expected.Index(missing[0]))
+	}
+
+	res := tdSetResult{
+		Kind:    itemsSetResult,
+		Missing: make([]reflect.Value, len(missing)),
+		Extra:   make([]reflect.Value, len(extra)),
+	}
+	for idx, expectedIdx := range missing {
+		res.Missing[idx] = expected.Index(expectedIdx)
+	}
+	for idx, gotIdx := range extra {
+		res.Extra[idx] = got.Index(gotIdx)
+	}
+	res.Missing = groupSetItems(res.Missing)
+	res.Extra = groupSetItems(res.Extra)
+
+	return &Error{
+		Context: ctx,
+		Message: "comparing unordered slice",
+		Summary: res,
+	}
+}
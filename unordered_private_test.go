@@ -0,0 +1,218 @@
+// Copyright (c) 2018, Maxime Soulé
+// All rights reserved.
+//
+// This source code is licensed under the BSD-style license found in the
+// LICENSE file in the root directory of this source tree.
+
+package testdeep
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnorderedSlices(t *testing.T) {
+	type role struct {
+		Name  string
+		Perms []string
+	}
+	type user struct {
+		Name  string
+		Roles []role
+		Tags  []string
+	}
+
+	got := []user{
+		{
+			Name: "Bob",
+			Roles: []role{
+				{Name: "admin", Perms: []string{"write", "read"}},
+				{Name: "guest", Perms: []string{"read"}},
+			},
+			Tags: []string{"b", "a"},
+		},
+		{
+			Name:  "Alice",
+			Roles: []role{{Name: "guest", Perms: []string{"read"}}},
+			Tags:  []string{"c"},
+		},
+	}
+	expected := []user{
+		{
+			Name:  "Alice",
+			Roles: []role{{Name: "guest", Perms: []string{"read"}}},
+			Tags:  []string{"c"},
+		},
+		{
+			Name: "Bob",
+			Roles: []role{
+				{Name: "guest", Perms: []string{"read"}},
+				{Name: "admin", Perms: []string{"read", "write"}},
+			},
+			Tags: []string{"a", "b"},
+		},
+	}
+
+	check := func(config ContextConfig, got, expected interface{}) *Error {
+		t.Helper()
+
+		ctx := NewContextWithConfig("DATA", config)
+		vgot, vexpected := reflect.ValueOf(got), reflect.ValueOf(expected)
+
+		err := deepValueEqual(ctx, vgot, vexpected)
+		if (deepValueEqual(ctx.boolean(), vgot, vexpected) == nil) != (err == nil) {
+			t.Errorf("boolean and non-boolean contexts disagree: %v", err)
+		}
+		return err
+	}
+
+	if check(ContextConfig{}, got, expected) == nil {
+		t.Error("ordered comparison should fail")
+	}
+
+	config := ContextConfig{UnorderedSlices: true}
+	if err := check(config, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Composes with operators
+	if err := check(config, got[0], Struct(user{Name: "Bob"}, StructFields{
+		"Roles": []role{
+			{Name: "guest", Perms: []string{"read"}},
+			{Name: "admin", Perms: []string{"read", "write"}},
+		},
+		"Tags": []string{"a", "b"},
+	})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := check(config,
+		map[string][]int{"a": {3, 1, 2}},
+		Map(map[string][]int{}, MapEntries{"a": []int{1, 2, 3}})); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Items are paired so that all of them match, even if an operator
+	// matches several items
+	if err := check(config, []interface{}{1, 2}, []interface{}{Gt(0), 1}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := check(config,
+		[]interface{}{1, 2, 3, 4},
+		[]interface{}{Gt(0), Gt(1), Gt(2), 3}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// Duplicates count
+	err := check(config, []int{1, 1, 2}, []int{1, 2, 2})
+	if err == nil {
+		t.Error("duplicates should be taken into account")
+	} else {
+		equalStr(t, err.Context.Path(), "DATA[1]")
+		equalStr(t, err.Message, "values differ")
+	}
+
+	err = check(config, []int{1, 2, 3, 4}, []int{5, 4, 3, 6})
+	if err == nil {
+		t.Error("unordered comparison should fail")
+	} else {
+		equalStr(t, err.Context.Path(), "DATA")
+		equalStr(t, err.Message, "comparing unordered slice")
+		equalStr(t, err.Summary.(tdSetResult).String(),
+			"Missing items: ((int) 5,\n                (int) 6)\n"+
+				"  Extra items: ((int) 1,\n                (int) 2)")
+	}
+
+	// Precise difference when only one item differs
+	err = check(config, got, []user{expected[0], {
+		Name: "Bob",
+		Roles: []role{
+			{Name: "guest", Perms: []string{"read"}},
+			{Name: "admin", Perms: []string{"read", "delete"}},
+		},
+		Tags: []string{"a", "b"},
+	}})
+	if err == nil {
+		t.Error("unordered comparison should fail")
+	} else {
+		equalStr(t, err.Context.Path(), "DATA[0].Roles[0].Perms[0]")
+	}
+
+	//
+	// By item type
+	config = ContextConfig{UnorderedSliceTypes: []reflect.Type{
+		reflect.TypeOf(user{}), reflect.TypeOf(role{}),
+	}}
+	err = check(config, got, expected)
+	if err == nil {
+		t.Error("[]string should be compared in order")
+	} else if !strings.HasPrefix(err.Context.Path(), "DATA[0].") ||
+		!strings.Contains(err.Context.Path(), "[0]") {
+		t.Errorf("unexpected error path: %s", err.Context.Path())
+	}
+
+	config.UnorderedSliceTypes = append(config.UnorderedSliceTypes,
+		reflect.TypeOf(""))
+	if err = check(config, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	//
+	// By path
+	config = ContextConfig{UnorderedSlicePaths: []string{
+		"", "/*/Roles", "/*/Roles/*/Perms", "=~^/\\d+/Tags$",
+	}}
+	if err = check(config, got, expected); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	config.UnorderedSlicePaths = []string{"", "/*/Roles", "/*/Tags"}
+	err = check(config, got, expected)
+	if err == nil {
+		t.Error("Perms should be compared in order")
+	} else {
+		equalStr(t, err.Context.Path(), "DATA[0].Roles[0].Perms[0]")
+	}
+
+	// Only the root slice
+	config.UnorderedSlicePaths = []string{""}
+	if err = check(config, []int{2, 1}, []int{1, 2}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err = check(config, [][]int{{2, 1}}, [][]int{{1, 2}}); err == nil {
+		t.Error("nested slice should be compared in order")
+	}
+
+	// Paths are only tracked in boolean Contexts while a pattern can
+	// match them
+	config.UnorderedSlicePaths = []string{"/*/Roles"}
+	ctx := NewContextWithConfig("DATA", config).boolean()
+	if ctx.slicePaths == nil || ctx.slicePaths.maxDepth != 2 {
+		t.Fatalf("bad compiled paths: %+v", ctx.slicePaths)
+	}
+	ctx = ctx.AddArrayIndex(0).AddPtr(1).AddField("Roles")
+	equalStr(t, ctx.Segments().JSONPointer(), "/0/Roles")
+	if ctx = ctx.AddArrayIndex(1); ctx.path != nil {
+		t.Errorf("path should not be tracked beyond depth 2: %s", ctx.Path())
+	}
+	config.UnorderedSlicePaths = []string{"/*/Roles", "=~Perms$"}
+	if paths := getSlicePaths(config.UnorderedSlicePaths); paths.maxDepth != -1 {
+		t.Errorf("regexp depth should be unbounded, not %d", paths.maxDepth)
+	}
+
+	// Patterns are compiled once per config
+	if getSlicePaths(config.UnorderedSlicePaths) !=
+		NewContextWithConfig("DATA", config).slicePaths {
+		t.Error("patterns should be compiled once")
+	}
+	config.UnorderedSlicePaths[1] = "/*/Tags"
+	if paths := getSlicePaths(config.UnorderedSlicePaths); paths.maxDepth != 2 {
+		t.Errorf("modified patterns should be compiled again: %+v", paths)
+	}
+
+	// Operators explicitly comparing in order are not affected
+	if err = check(ContextConfig{UnorderedSlices: true},
+		[]int{2, 1}, Slice([]int{}, ArrayEntries{0: 1, 1: 2})); err == nil {
+		t.Error("Slice operator should compare in order")
+	}
+}
@@ -25,6 +25,12 @@ func ternStr(cond bool, a, b string) string {
 	return b
 }
 
+// unexportedSummary is the summary of errors raised when an
+// unexported field cannot be accessed, typically when the unsafe
+// package is disabled (see UnsafeDisabled).
+const unexportedSummary = rawString(
+	"unsafe package disabled, see ContextConfig.IgnoreUnexported to skip such fields")
+
 // getTime returns the time.Time that is inside got or that can be
 // converted from got contents.
 func getTime(ctx Context, got reflect.Value, mustConvert bool) (time.Time, *Error) {
@@ -44,7 +50,307 @@ func getTime(ctx Context, got reflect.Value, mustConvert bool) (time.Time, *Erro
 		return time.Time{}, &Error{
 			Context: ctx,
 			Message: "cannot compare unexported field that cannot be overridden",
+			Summary: unexportedSummary,
 		}
 	}
 	return gotIf.(time.Time), nil
 }
+
+// levenshtein returns the Levenshtein distance between "a" and "b",
+// rune-wise.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// compareOrdered compares "a" and "b", both of the same type whose
+// kind is an integer, a float or a string, or both time.Time (or
+// convertible), or whose type has an order method (see
+// getOrderFunc). The order method takes precedence over the kind of
+// the type. It returns -1, 0 or 1 if "a" is respectively lesser than,
+// equal to or greater than "b", and false if they cannot be ordered.
+func compareOrdered(a, b reflect.Value) (int, bool) {
+	if a.Type() != b.Type() {
+		return 0, false
+	}
+
+	if order := orderMethodOf(a.Type()); order != nil {
+		return order(a, b)
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ai, bi := a.Int(), b.Int()
+		return cmpBool(ai < bi, ai > bi), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		au, bu := a.Uint(), b.Uint()
+		return cmpBool(au < bu, au > bu), true
+
+	case reflect.Float32, reflect.Float64:
+		af, bf := a.Float(), b.Float()
+		return cmpBool(af < bf, af > bf), true
+
+	case reflect.String:
+		as, bs := a.String(), b.String()
+		return cmpBool(as < bs, as > bs), true
+
+	case reflect.Struct:
+		if a.Type().ConvertibleTo(timeType) {
+			at, aok := getInterface(a.Convert(timeType), true)
+			bt, bok := getInterface(b.Convert(timeType), true)
+			if aok && bok {
+				return cmpBool(at.(time.Time).Before(bt.(time.Time)),
+					at.(time.Time).After(bt.(time.Time))), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// orderFunc compares "a" and "b" and returns -1, 0 or 1 if "a" is
+// respectively lesser than, equal to or greater than "b", and false
+// if one of them is a nil pointer.
+type orderFunc func(a, b reflect.Value) (int, bool)
+
+// getOrderFunc returns the orderFunc of "typ" if it has a Compare(T)
+// int, a Cmp(T) int (as *big.Int, *big.Float and *big.Rat) or a
+// Less(T) bool method, T being "typ" itself. It returns nil
+// otherwise. The result is cached per type, see getTypeInfo.
+func getOrderFunc(typ reflect.Type) orderFunc {
+	return getTypeInfo(typ).order
+}
+
+// orderMethodOf returns the orderFunc to use to compare values of
+// "typ" instead of relying on its kind, nil if none. time.Time (and
+// convertible) types are never compared using their order method.
+func orderMethodOf(typ reflect.Type) orderFunc {
+	if typ.Kind() == reflect.Struct && typ.ConvertibleTo(timeType) {
+		return nil
+	}
+	return getOrderFunc(typ)
+}
+
+// newOrderFunc does the job of getOrderFunc, without any cache.
+func newOrderFunc(typ reflect.Type) orderFunc {
+	if typ.Kind() == reflect.Interface {
+		return nil
+	}
+
+	method := func(name string, outKind reflect.Kind) reflect.Value {
+		m, ok := typ.MethodByName(name)
+		if ok && m.Type.NumIn() == 2 && m.Type.In(1) == typ &&
+			m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == outKind {
+			return m.Func
+		}
+		return reflect.Value{}
+	}
+
+	call := func(fn, a, b reflect.Value) (reflect.Value, bool) {
+		if typ.Kind() == reflect.Ptr && (a.IsNil() || b.IsNil()) {
+			return reflect.Value{}, false
+		}
+		// Values got using unexported fields cannot be passed as is
+		for _, v := range []*reflect.Value{&a, &b} {
+			if !v.CanInterface() {
+				vi, _ := getInterface(*v, true)
+				*v = reflect.ValueOf(vi)
+			}
+		}
+		return fn.Call([]reflect.Value{a, b})[0], true
+	}
+
+	for _, name := range []string{"Compare", "Cmp"} {
+		if fn := method(name, reflect.Int); fn.IsValid() {
+			return func(a, b reflect.Value) (int, bool) {
+				res, ok := call(fn, a, b)
+				if !ok {
+					return 0, false
+				}
+				return cmpBool(res.Int() < 0, res.Int() > 0), true
+			}
+		}
+	}
+
+	if fn := method("Less", reflect.Bool); fn.IsValid() {
+		return func(a, b reflect.Value) (int, bool) {
+			less, ok := call(fn, a, b)
+			if !ok {
+				return 0, false
+			}
+			greater, _ := call(fn, b, a)
+			return cmpBool(less.Bool(), greater.Bool()), true
+		}
+	}
+
+	return nil
+}
+
+// isSignedInt returns true if the kind of "v" is a signed integer.
+func isSignedInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// compareNumbers compares "a" and "b", both integers (signed or
+// not, of any width) or both floats, without any loss. It returns -1,
+// 0 or 1 if "a" is respectively lesser than, equal to or greater
+// than "b".
+func compareNumbers(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		af, bf := a.Float(), b.Float()
+		return cmpBool(af < bf, af > bf)
+	}
+
+	aSigned, bSigned := isSignedInt(a), isSignedInt(b)
+	switch {
+	case aSigned && bSigned:
+		ai, bi := a.Int(), b.Int()
+		return cmpBool(ai < bi, ai > bi)
+
+	case aSigned:
+		if a.Int() < 0 {
+			return -1
+		}
+		au, bu := uint64(a.Int()), b.Uint()
+		return cmpBool(au < bu, au > bu)
+
+	case bSigned:
+		if b.Int() < 0 {
+			return 1
+		}
+		au, bu := a.Uint(), uint64(b.Int())
+		return cmpBool(au < bu, au > bu)
+	}
+
+	au, bu := a.Uint(), b.Uint()
+	return cmpBool(au < bu, au > bu)
+}
+
+// numberFamily returns 1 if "typ" is a predeclared integer type, 2
+// if it is a predeclared float type, and 0 otherwise. Values of the
+// same family can be compared with compareNumbers.
+func numberFamily(typ reflect.Type) int {
+	if typ.PkgPath() != "" {
+		return 0
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 1
+	case reflect.Float32, reflect.Float64:
+		return 2
+	}
+	return 0
+}
+
+func cmpBool(lesser, greater bool) int {
+	switch {
+	case lesser:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// funcArg returns "v" ready to be passed as a "typ" parameter of a
+// function, following "v" interface if needed. It returns false if
+// "v" is not assignable to "typ".
+func funcArg(v reflect.Value, typ reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(typ) {
+		return v, true
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() &&
+		v.Elem().Type().AssignableTo(typ) {
+		return v.Elem(), true
+	}
+	return v, false
+}
+
+// getKindValue returns the value behind "got", dereferencing it if it
+// is a pointer, if its kind is one of "kinds". Otherwise an *Error
+// located at "td" is returned, "expected" describing the accepted
+// types.
+func getKindValue(ctx Context, got reflect.Value, td TestDeep, expected string, kinds ...reflect.Kind) (reflect.Value, *Error) {
+	if !got.IsValid() {
+		if ctx.booleanError {
+			return got, booleanError
+		}
+		return got, &Error{
+			Context:  ctx,
+			Message:  "nil value",
+			Got:      rawString("nil"),
+			Expected: rawString(expected),
+			Location: td.GetLocation(),
+		}
+	}
+
+	value := got
+	if got.Kind() == reflect.Ptr {
+		value = got.Elem()
+		if !value.IsValid() {
+			if ctx.booleanError {
+				return got, booleanError
+			}
+			return got, &Error{
+				Context:  ctx,
+				Message:  "nil pointer",
+				Got:      rawString("nil " + got.Type().String()),
+				Expected: rawString(expected),
+				Location: td.GetLocation(),
+			}
+		}
+	}
+
+	for _, kind := range kinds {
+		if value.Kind() == kind {
+			return value, nil
+		}
+	}
+
+	if ctx.booleanError {
+		return got, booleanError
+	}
+	return got, &Error{
+		Context:  ctx,
+		Message:  "bad type",
+		Got:      rawString(got.Type().String()),
+		Expected: rawString(expected),
+		Location: td.GetLocation(),
+	}
+}